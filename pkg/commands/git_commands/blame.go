@@ -2,6 +2,8 @@ package git_commands
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 )
 
 type BlameCommands struct {
@@ -31,3 +33,60 @@ func (self *BlameCommands) BlameLineRange(filename string, commit string, firstL
 
 	return self.cmd.New(cmdArgs.ToArgv()).RunWithOutput()
 }
+
+// BlameInfo describes the commit that last changed a line
+type BlameInfo struct {
+	Hash       string
+	AuthorName string
+	// the author date, as a unix timestamp
+	UnixTimestamp int64
+	// the content of the line itself
+	Line string
+}
+
+// BlameWorkingTreeLine blames a single line (counting from 1) of the file as it
+// is in the working tree. A line that hasn't been committed yet is attributed
+// to the all-zero hash and the author "Not Committed Yet".
+func (self *BlameCommands) BlameWorkingTreeLine(filename string, lineNumber int) (*BlameInfo, error) {
+	cmdArgs := NewGitCmd("blame").
+		Arg("--porcelain").
+		Arg(fmt.Sprintf("-L%d,+1", lineNumber)).
+		Arg("--").
+		Arg(filename).
+		ToArgv()
+
+	output, err := self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+
+	return parseBlamePorcelain(output)
+}
+
+// The porcelain format starts with a line containing the hash, followed by
+// "key value" lines describing the commit, and ends with the content of the
+// line prefixed by a tab.
+func parseBlamePorcelain(output string) (*BlameInfo, error) {
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+	if len(lines) == 0 || lines[0] == "" {
+		return nil, fmt.Errorf("unexpected output of git blame: %q", output)
+	}
+
+	info := &BlameInfo{Hash: strings.Split(lines[0], " ")[0]}
+	for _, line := range lines[1:] {
+		if content, ok := strings.CutPrefix(line, "\t"); ok {
+			info.Line = content
+			continue
+		}
+
+		key, value, _ := strings.Cut(line, " ")
+		switch key {
+		case "author":
+			info.AuthorName = value
+		case "author-time":
+			info.UnixTimestamp, _ = strconv.ParseInt(value, 10, 64)
+		}
+	}
+
+	return info, nil
+}
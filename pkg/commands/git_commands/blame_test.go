@@ -0,0 +1,87 @@
+package git_commands
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlameWorkingTreeLine(t *testing.T) {
+	type scenario struct {
+		testName      string
+		output        string
+		expectedInfo  *BlameInfo
+		expectedError string
+	}
+
+	scenarios := []scenario{
+		{
+			testName: "committed line",
+			output: `ac90ebac688fe8bc2ffd922157a9d2c54681d2aa 11 12 1
+author Stefan Haller
+author-mail <stefan@haller-berlin.de>
+author-time 1690894496
+author-tz +0200
+committer Stefan Haller
+committer-mail <stefan@haller-berlin.de>
+committer-time 1690894496
+committer-tz +0200
+summary Add BlameCommands
+filename pkg/commands/git_commands/blame.go
+	return &BlameCommands{
+`,
+			expectedInfo: &BlameInfo{
+				Hash:          "ac90ebac688fe8bc2ffd922157a9d2c54681d2aa",
+				AuthorName:    "Stefan Haller",
+				UnixTimestamp: 1690894496,
+				Line:          "return &BlameCommands{",
+			},
+		},
+		{
+			testName: "uncommitted line",
+			output: `0000000000000000000000000000000000000000 12 12 1
+author Not Committed Yet
+author-mail <not.committed.yet>
+author-time 1700000000
+author-tz +0000
+committer Not Committed Yet
+committer-mail <not.committed.yet>
+committer-time 1700000000
+committer-tz +0000
+summary Version of blame.go from blame.go
+previous ac90ebac688fe8bc2ffd922157a9d2c54681d2aa blame.go
+filename blame.go
+	new line
+`,
+			expectedInfo: &BlameInfo{
+				Hash:          "0000000000000000000000000000000000000000",
+				AuthorName:    "Not Committed Yet",
+				UnixTimestamp: 1700000000,
+				Line:          "new line",
+			},
+		},
+		{
+			testName:      "no output",
+			output:        "",
+			expectedError: `unexpected output of git blame: ""`,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			runner := oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"blame", "--porcelain", "-L12,+1", "--", "blame.go"}, s.output, nil)
+			instance := buildBlameCommands(commonDeps{runner: runner})
+
+			info, err := instance.BlameWorkingTreeLine("blame.go", 12)
+			if s.expectedError != "" {
+				assert.EqualError(t, err, s.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, s.expectedInfo, info)
+			}
+			runner.CheckForMissingCalls()
+		})
+	}
+}
@@ -2,9 +2,11 @@ package git_commands
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/go-errors/errors"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
 )
 
@@ -73,6 +75,21 @@ func AddCoAuthorToDescription(description string, author string) string {
 	return description + fmt.Sprintf("Co-authored-by: %s", author)
 }
 
+var coAuthorTrailerRegex = regexp.MustCompile(`(?i)^co-authored-by:\s*(.*?)\s*(<[^>]*>)?\s*$`)
+
+// ParseCoAuthors returns the names of the people given in the Co-authored-by
+// trailers of a commit message, without their email addresses
+func ParseCoAuthors(message string) []string {
+	coAuthors := []string{}
+	for _, line := range strings.Split(message, "\n") {
+		match := coAuthorTrailerRegex.FindStringSubmatch(strings.TrimSpace(line))
+		if match != nil && match[1] != "" {
+			coAuthors = append(coAuthors, match[1])
+		}
+	}
+	return coAuthors
+}
+
 // ResetToCommit reset to commit
 func (self *CommitCommands) ResetToCommit(hash string, strength string, envVars []string) error {
 	cmdArgs := NewGitCmd("reset").Arg("--"+strength, hash).ToArgv()
@@ -165,6 +182,17 @@ func (self *CommitCommands) GetCommitMessage(commitHash string) (string, error)
 	return strings.ReplaceAll(strings.TrimSpace(message), "\r\n", "\n"), err
 }
 
+// GetCoAuthors fetches the message of the given commit and returns the names of
+// its co-authors. The commit loader doesn't load full messages, so this is
+// meant to be called on demand, e.g. for the commits that are visible.
+func (self *CommitCommands) GetCoAuthors(commitHash string) ([]string, error) {
+	message, err := self.GetCommitMessage(commitHash)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCoAuthors(message), nil
+}
+
 func (self *CommitCommands) GetCommitSubject(commitHash string) (string, error) {
 	cmdArgs := NewGitCmd("log").
 		Arg("--format=%s", "--max-count=1", commitHash).
@@ -175,13 +203,76 @@ func (self *CommitCommands) GetCommitSubject(commitHash string) (string, error)
 	return strings.TrimSpace(subject), err
 }
 
-func (self *CommitCommands) GetCommitDiff(commitHash string) (string, error) {
-	cmdArgs := NewGitCmd("show").Arg("--no-color", commitHash).ToArgv()
+func (self *CommitCommands) GetCommitDiff(commitHash string, additionalArgs ...string) (string, error) {
+	cmdArgs := NewGitCmd("show").Arg("--no-color").Arg(additionalArgs...).Arg(commitHash).ToArgv()
 
 	diff, err := self.cmd.New(cmdArgs).DontLog().RunWithOutput()
 	return diff, err
 }
 
+// GetCommitDiffForPath returns the diff of the given commit, limited to the
+// changes under the given path, i.e. `git show commit -- path`
+func (self *CommitCommands) GetCommitDiffForPath(commitHash string, path string, additionalArgs ...string) (string, error) {
+	cmdArgs := NewGitCmd("show").Arg("--no-color").Arg(additionalArgs...).Arg(commitHash, "--", path).ToArgv()
+
+	return self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+}
+
+// GetRangeDiff returns the diff between the merge base of the two commits and
+// the second one, i.e. `git diff from...to`
+func (self *CommitCommands) GetRangeDiff(from string, to string, additionalArgs ...string) (string, error) {
+	cmdArgs := NewGitCmd("diff").Arg("--no-color").Arg(additionalArgs...).Arg(from + "..." + to).ToArgv()
+
+	return self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+}
+
+// GetCombinedRangeDiff returns the changes of all commits from the first one
+// (inclusive) up to the last one as a single diff, i.e. `git diff first^ last`.
+// If the first commit is a root commit, its changes are diffed against the
+// empty tree.
+func (self *CommitCommands) GetCombinedRangeDiff(first *models.Commit, last *models.Commit, additionalArgs ...string) (string, error) {
+	from := first.Hash + "^"
+	if first.IsFirstCommit() {
+		from = models.EmptyTreeCommitHash
+	}
+	cmdArgs := NewGitCmd("diff").Arg("--no-color").Arg(additionalArgs...).Arg(from, last.Hash).ToArgv()
+
+	return self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+}
+
+// GetFormatPatch returns the given commit as an mbox-style patch, ready to be
+// emailed, i.e. `git format-patch -1 --stdout commit`
+func (self *CommitCommands) GetFormatPatch(commitHash string) (string, error) {
+	cmdArgs := NewGitCmd("format-patch").Arg("-1", "--stdout", "--no-color", commitHash).ToArgv()
+
+	return self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+}
+
+// GetDiffStat returns the files changed by the given commit along with their
+// numbers of added and deleted lines, followed by a summary line, i.e. the
+// output of `git show --stat` without the commit header
+func (self *CommitCommands) GetDiffStat(commitHash string) (string, error) {
+	cmdArgs := NewGitCmd("show").Arg("--stat", "--format=", "--no-color", commitHash).ToArgv()
+
+	return self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+}
+
+// GetDiffAgainstWorkingTree returns the diff between the given commit and the
+// working tree, including uncommitted changes, i.e. `git diff commit`
+func (self *CommitCommands) GetDiffAgainstWorkingTree(commitHash string, additionalArgs ...string) (string, error) {
+	cmdArgs := NewGitCmd("diff").Arg("--no-color").Arg(additionalArgs...).Arg(commitHash).ToArgv()
+
+	return self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+}
+
+// GetTreeFileList returns the paths of all files tracked at the given commit,
+// one per line, i.e. `git ls-tree -r --name-only commit`
+func (self *CommitCommands) GetTreeFileList(commitHash string) (string, error) {
+	cmdArgs := NewGitCmd("ls-tree").Arg("-r", "--name-only", commitHash).ToArgv()
+
+	return self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+}
+
 type Author struct {
 	Name  string
 	Email string
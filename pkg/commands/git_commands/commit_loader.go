@@ -282,7 +282,7 @@ func (self *CommitLoader) getHydratedRebasingCommits(rebaseMode enums.RebaseMode
 	findFullCommit := lo.Ternary(self.version.IsOlderThan(2, 25, 2),
 		func(hash string) *models.Commit {
 			for s, c := range fullCommits {
-				if strings.HasPrefix(s, hash) {
+				if utils.EqualHashes(s, hash) {
 					return c
 				}
 			}
@@ -456,7 +456,7 @@ func setCommitMergedStatuses(ancestor string, commits []*models.Commit) {
 	passedAncestor := false
 	for i, commit := range commits {
 		// some commits aren't really commits and don't have hashes, such as the update-ref todo
-		if commit.Hash != "" && strings.HasPrefix(ancestor, commit.Hash) {
+		if utils.EqualHashes(ancestor, commit.Hash) {
 			passedAncestor = true
 		}
 		if commit.Status != models.StatusPushed && commit.Status != models.StatusUnpushed {
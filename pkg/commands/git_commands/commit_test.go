@@ -459,3 +459,32 @@ func TestAddCoAuthorToDescription(t *testing.T) {
 		})
 	}
 }
+
+func TestParseCoAuthors(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		message        string
+		expectedResult []string
+	}{
+		{
+			name:           "No co-authors",
+			message:        "Subject\n\nBody",
+			expectedResult: []string{},
+		},
+		{
+			name:           "Co-authors with and without email",
+			message:        "Subject\n\nBody\n\nCo-authored-by: Jane Smith <jane@smith.com>\nco-authored-by:  John Doe ",
+			expectedResult: []string{"Jane Smith", "John Doe"},
+		},
+		{
+			name:           "Trailer without a name",
+			message:        "Subject\n\nCo-authored-by: <jane@smith.com>",
+			expectedResult: []string{},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			assert.Equal(t, s.expectedResult, ParseCoAuthors(s.message))
+		})
+	}
+}
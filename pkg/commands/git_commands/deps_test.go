@@ -117,6 +117,11 @@ func buildCommitCommands(deps commonDeps) *CommitCommands {
 	return NewCommitCommands(gitCommon)
 }
 
+func buildBlameCommands(deps commonDeps) *BlameCommands {
+	gitCommon := buildGitCommon(deps)
+	return NewBlameCommands(gitCommon)
+}
+
 func buildWorkingTreeCommands(deps commonDeps) *WorkingTreeCommands {
 	gitCommon := buildGitCommon(deps)
 	submoduleCommands := buildSubmoduleCommands(deps)
@@ -80,6 +80,17 @@ func (self *StashCommands) Hash(index int) (string, error) {
 	return strings.Trim(hash, "\r\n"), err
 }
 
+// GetStashDiff returns the changes of the given stash entry as a plain patch,
+// i.e. `git stash show -p stash@{index}`
+func (self *StashCommands) GetStashDiff(index int, additionalArgs ...string) (string, error) {
+	cmdArgs := NewGitCmd("stash").Arg("show", "-p", "--no-color").
+		Arg(additionalArgs...).
+		Arg(fmt.Sprintf("stash@{%d}", index)).
+		ToArgv()
+
+	return self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+}
+
 func (self *StashCommands) ShowStashEntryCmdObj(index int) oscommands.ICmdObj {
 	// "-u" is the same as "--include-untracked", but the latter fails in older git versions for some reason
 	cmdArgs := NewGitCmd("stash").Arg("show").
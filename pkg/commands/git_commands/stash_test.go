@@ -96,6 +96,17 @@ func TestStashHash(t *testing.T) {
 	runner.CheckForMissingCalls()
 }
 
+func TestStashGetStashDiff(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"stash", "show", "-p", "--no-color", "--unified=0", "stash@{2}"}, "diff --git a/file b/file\n", nil)
+	instance := buildStashCommands(commonDeps{runner: runner})
+
+	diff, err := instance.GetStashDiff(2, "--unified=0")
+	assert.NoError(t, err)
+	assert.Equal(t, "diff --git a/file b/file\n", diff)
+	runner.CheckForMissingCalls()
+}
+
 func TestStashStashEntryCmdObj(t *testing.T) {
 	type scenario struct {
 		testName            string
@@ -287,7 +287,31 @@ func (self *WorkingTreeCommands) ShowFileDiff(from string, to string, reverse bo
 }
 
 func (self *WorkingTreeCommands) ShowFileDiffCmdObj(from string, to string, reverse bool, fileName string, plain bool) oscommands.ICmdObj {
+	return self.ShowFilesDiffCmdObj(from, to, reverse, []string{fileName}, plain, FilesDiffOptions{})
+}
+
+// FilesDiffOptions holds the less common knobs of ShowFilesDiffCmdObj. The zero
+// value gives the same diff as the one shown in the main view.
+type FilesDiffOptions struct {
+	// Detect renamed files rather than showing them as a deletion plus an
+	// addition. Note that a rename is only detected if both the old and the new
+	// path are included in the diff.
+	DetectRenames bool
+	// Mark changes within a line as [-removed-]{+added+} words instead of
+	// showing whole removed and added lines
+	WordDiff bool
+	// Ignore changes in whitespace, regardless of whether the diff view does
+	IgnoreWhitespace bool
+	// If set, the number of context lines to use instead of the diff view's
+	ContextSize *uint64
+}
+
+// ShowFilesDiffCmdObj is like ShowFileDiffCmdObj but restricts the diff to several paths at once
+func (self *WorkingTreeCommands) ShowFilesDiffCmdObj(from string, to string, reverse bool, fileNames []string, plain bool, opts FilesDiffOptions) oscommands.ICmdObj {
 	contextSize := self.AppState.DiffContextSize
+	if opts.ContextSize != nil {
+		contextSize = *opts.ContextSize
+	}
 
 	colorArg := self.UserConfig().Git.Paging.ColorArg
 	if plain {
@@ -303,14 +327,17 @@ func (self *WorkingTreeCommands) ShowFileDiffCmdObj(from string, to string, reve
 		ArgIfElse(useExtDiff, "--ext-diff", "--no-ext-diff").
 		Arg("--submodule").
 		Arg(fmt.Sprintf("--unified=%d", contextSize)).
-		Arg("--no-renames").
+		ArgIfElse(opts.DetectRenames,
+			fmt.Sprintf("--find-renames=%d%%", self.AppState.RenameSimilarityThreshold),
+			"--no-renames").
 		Arg(fmt.Sprintf("--color=%s", colorArg)).
+		ArgIf(opts.WordDiff, "--word-diff=plain").
 		Arg(from).
 		Arg(to).
 		ArgIf(reverse, "-R").
-		ArgIf(!plain && self.AppState.IgnoreWhitespaceInDiffView, "--ignore-all-space").
+		ArgIf(opts.IgnoreWhitespace || (!plain && self.AppState.IgnoreWhitespaceInDiffView), "--ignore-all-space").
 		Arg("--").
-		Arg(fileName).
+		Arg(fileNames...).
 		Dir(self.repoPaths.worktreePath).
 		ToArgv()
 
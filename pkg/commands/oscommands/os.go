@@ -285,6 +285,26 @@ func PrepareForChildren(cmd *exec.Cmd) {
 }
 
 func (c *OSCommand) CopyToClipboard(str string) error {
+	c.logCopyToClipboard(str)
+	if c.UserConfig().OS.CopyToClipboardCmd != "" {
+		return c.copyToClipboardWithCmd(c.UserConfig().OS.CopyToClipboardCmd, str)
+	}
+
+	return clipboard.WriteAll(str)
+}
+
+// CopyDiffToClipboard is like CopyToClipboard, but for diffs and patches: it
+// uses OS.CopyDiffToClipboardCmd if that is set.
+func (c *OSCommand) CopyDiffToClipboard(str string) error {
+	if c.UserConfig().OS.CopyDiffToClipboardCmd == "" {
+		return c.CopyToClipboard(str)
+	}
+
+	c.logCopyToClipboard(str)
+	return c.copyToClipboardWithCmd(c.UserConfig().OS.CopyDiffToClipboardCmd, str)
+}
+
+func (c *OSCommand) logCopyToClipboard(str string) {
 	escaped := strings.Replace(str, "\n", "\\n", -1)
 	truncated := utils.TruncateWithEllipsis(escaped, 40)
 
@@ -295,14 +315,13 @@ func (c *OSCommand) CopyToClipboard(str string) error {
 		},
 	)
 	c.LogCommand(msg, false)
-	if c.UserConfig().OS.CopyToClipboardCmd != "" {
-		cmdStr := utils.ResolvePlaceholderString(c.UserConfig().OS.CopyToClipboardCmd, map[string]string{
-			"text": c.Cmd.Quote(str),
-		})
-		return c.Cmd.NewShell(cmdStr).Run()
-	}
+}
 
-	return clipboard.WriteAll(str)
+func (c *OSCommand) copyToClipboardWithCmd(cmdTemplate string, str string) error {
+	cmdStr := utils.ResolvePlaceholderString(cmdTemplate, map[string]string{
+		"text": c.Cmd.Quote(str),
+	})
+	return c.Cmd.NewShell(cmdStr).Run()
 }
 
 func (c *OSCommand) PasteFromClipboard() (string, error) {
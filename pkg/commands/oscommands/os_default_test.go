@@ -140,3 +140,34 @@ func TestOSCommandOpenFileLinux(t *testing.T) {
 		s.test(oSCmd.OpenFile(s.filename))
 	}
 }
+
+func TestOSCommandCopyDiffToClipboard(t *testing.T) {
+	scenarios := []struct {
+		name                   string
+		copyDiffToClipboardCmd string
+		expectedArgs           []string
+	}{
+		{
+			name:                   "falls back to copyToClipboardCmd",
+			copyDiffToClipboardCmd: "",
+			expectedArgs:           []string{"bash", "-c", `copy "diff"`},
+		},
+		{
+			name:                   "uses copyDiffToClipboardCmd when set",
+			copyDiffToClipboardCmd: "copy-diff {{text}}",
+			expectedArgs:           []string{"bash", "-c", `copy-diff "diff"`},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			runner := NewFakeRunner(t).ExpectArgs(s.expectedArgs, "", nil)
+			osCommand := NewDummyOSCommandWithRunner(runner)
+			osCommand.UserConfig().OS.CopyToClipboardCmd = "copy {{text}}"
+			osCommand.UserConfig().OS.CopyDiffToClipboardCmd = s.copyDiffToClipboardCmd
+
+			assert.NoError(t, osCommand.CopyDiffToClipboard("diff"))
+			runner.CheckForMissingCalls()
+		})
+	}
+}
@@ -142,7 +142,12 @@ type GuiConfig struct {
 	// Length of author name in expanded commits view. 2 means show initials only.
 	CommitAuthorLongLength int `yaml:"commitAuthorLongLength"`
 	// Length of commit hash in commits view. 0 shows '*' if NF icons aren't on.
-	CommitHashLength int `yaml:"commitHashLength" jsonschema:"minimum=0"`
+	// Otherwise it must be between 4 and 40.
+	CommitHashLength int `yaml:"commitHashLength" jsonschema:"minimum=0,maximum=40"`
+	// Number of context lines of the diffs copied to the clipboard from the
+	// files, commits, commit files and stash views. -1 means the same number
+	// as in the diff view.
+	CopyDiffContextLines int `yaml:"copyDiffContextLines" jsonschema:"minimum=-1"`
 	// If true, show commit hashes alongside branch names in the branches view.
 	ShowBranchCommitHash bool `yaml:"showBranchCommitHash"`
 	// Whether to show the divergence from the base branch in the branches view.
@@ -424,6 +429,8 @@ type KeybindingUniversalConfig struct {
 	DiffingMenu                       string   `yaml:"diffingMenu"`
 	DiffingMenuAlt                    string   `yaml:"diffingMenu-alt"`
 	CopyToClipboard                   string   `yaml:"copyToClipboard"`
+	CopySelectedLine                  string   `yaml:"copySelectedLine"`
+	CopyViewContents                  string   `yaml:"copyViewContents"`
 	OpenRecentRepos                   string   `yaml:"openRecentRepos"`
 	SubmitEditorText                  string   `yaml:"submitEditorText"`
 	ExtrasMenu                        string   `yaml:"extrasMenu"`
@@ -528,9 +535,11 @@ type KeybindingCommitFilesConfig struct {
 }
 
 type KeybindingMainConfig struct {
-	ToggleSelectHunk string `yaml:"toggleSelectHunk"`
-	PickBothHunks    string `yaml:"pickBothHunks"`
-	EditSelectHunk   string `yaml:"editSelectHunk"`
+	ToggleSelectHunk        string `yaml:"toggleSelectHunk"`
+	PickBothHunks           string `yaml:"pickBothHunks"`
+	EditSelectHunk          string `yaml:"editSelectHunk"`
+	CopySelectedHunkAsPatch string `yaml:"copySelectedHunkAsPatch"`
+	CopyBlameInfo           string `yaml:"copyBlameInfo"`
 }
 
 type KeybindingSubmodulesConfig struct {
@@ -602,6 +611,11 @@ type OSConfig struct {
 	// See https://github.com/jesseduffield/lazygit/blob/master/docs/Config.md#custom-command-for-copying-to-and-pasting-from-clipboard
 	CopyToClipboardCmd string `yaml:"copyToClipboardCmd,omitempty"`
 
+	// CopyDiffToClipboardCmd, if set, is used instead of CopyToClipboardCmd for
+	// copying diffs and patches, e.g. to turn them into an image. Everything else
+	// (e.g. names and paths) is still copied with CopyToClipboardCmd.
+	CopyDiffToClipboardCmd string `yaml:"copyDiffToClipboardCmd,omitempty"`
+
 	// ReadFromClipboardCmd is the command for reading the clipboard.
 	// See https://github.com/jesseduffield/lazygit/blob/master/docs/Config.md#custom-command-for-copying-to-and-pasting-from-clipboard
 	ReadFromClipboardCmd string `yaml:"readFromClipboardCmd,omitempty"`
@@ -638,6 +652,9 @@ type CustomCommand struct {
 	ShowOutput *bool `yaml:"showOutput"`
 	// The title to display in the popup panel if showOutput is true. If left unset, the command will be used as the title.
 	OutputTitle string `yaml:"outputTitle"`
+	// If true, copy the command's output to the clipboard, without its trailing newlines
+	// [dev] Pointer to bool so that we can distinguish unset (nil) from false.
+	CopyOutputToClipboard *bool `yaml:"copyOutputToClipboard"`
 	// Actions to take after the command has completed
 	// [dev] Pointer so that we can tell whether it appears in the config file
 	After *CustomCommandAfterHook `yaml:"after"`
@@ -753,6 +770,7 @@ func GetDefaultConfig() *UserConfig {
 			CommitAuthorShortLength:      2,
 			CommitAuthorLongLength:       17,
 			CommitHashLength:             8,
+			CopyDiffContextLines:         -1,
 			ShowBranchCommitHash:         false,
 			ShowDivergenceFromBaseBranch: "none",
 			CommandLogSize:               8,
@@ -887,6 +905,8 @@ func GetDefaultConfig() *UserConfig {
 				DiffingMenu:                       "W",
 				DiffingMenuAlt:                    "<c-e>",
 				CopyToClipboard:                   "<c-o>",
+				CopySelectedLine:                  "<c-x>",
+				CopyViewContents:                  "<c-g>",
 				SubmitEditorText:                  "<enter>",
 				ExtrasMenu:                        "@",
 				ToggleWhitespaceInDiffView:        "<c-w>",
@@ -981,9 +1001,11 @@ func GetDefaultConfig() *UserConfig {
 				CheckoutCommitFile: "c",
 			},
 			Main: KeybindingMainConfig{
-				ToggleSelectHunk: "a",
-				PickBothHunks:    "b",
-				EditSelectHunk:   "E",
+				ToggleSelectHunk:        "a",
+				PickBothHunks:           "b",
+				EditSelectHunk:          "E",
+				CopySelectedHunkAsPatch: "y",
+				CopyBlameInfo:           "B",
 			},
 			Submodules: KeybindingSubmodulesConfig{
 				Init:     "i",
@@ -19,6 +19,12 @@ func (config *UserConfig) Validate() error {
 		[]string{"none", "onlyArrow", "arrowAndNumber"}); err != nil {
 		return err
 	}
+	if err := validateCommitHashLength(config.Gui.CommitHashLength); err != nil {
+		return err
+	}
+	if err := validateCopyDiffContextLines(config.Gui.CopyDiffContextLines); err != nil {
+		return err
+	}
 	if err := validateKeybindings(config.Keybinding); err != nil {
 		return err
 	}
@@ -36,6 +42,21 @@ func validateEnum(name string, value string, allowedValues []string) error {
 	return fmt.Errorf("Unexpected value '%s' for '%s'. Allowed values: %s", value, name, allowedValuesStr)
 }
 
+// 0 is allowed too, it means that the hash is hidden
+func validateCommitHashLength(length int) error {
+	if length == 0 || (length >= 4 && length <= 40) {
+		return nil
+	}
+	return fmt.Errorf("Unexpected value '%d' for 'gui.commitHashLength'. It must be 0 or between 4 and 40", length)
+}
+
+func validateCopyDiffContextLines(lines int) error {
+	if lines >= -1 {
+		return nil
+	}
+	return fmt.Errorf("Unexpected value '%d' for 'gui.copyDiffContextLines'. It must be -1 or a number of lines", lines)
+}
+
 func validateKeybindingsRecurse(path string, node any) error {
 	value := reflect.ValueOf(node)
 	if value.Kind() == reflect.Struct {
@@ -106,6 +127,7 @@ func validateCustomCommands(customCommands []CustomCommand) error {
 				customCommand.Stream != nil ||
 				customCommand.ShowOutput != nil ||
 				len(customCommand.OutputTitle) > 0 ||
+				customCommand.CopyOutputToClipboard != nil ||
 				customCommand.After != nil) {
 			commandRef := ""
 			if len(customCommand.Key) > 0 {
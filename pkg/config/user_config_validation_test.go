@@ -1,6 +1,7 @@
 package config
 
 import (
+	"strconv"
 	"strings"
 	"testing"
 
@@ -30,6 +31,33 @@ func TestUserConfigValidate_enums(t *testing.T) {
 				{value: "invalid_value", valid: false},
 			},
 		},
+		{
+			name: "Gui.CommitHashLength",
+			setup: func(config *UserConfig, value string) {
+				config.Gui.CommitHashLength, _ = strconv.Atoi(value)
+			},
+			testCases: []testCase{
+				{value: "0", valid: true},
+				{value: "4", valid: true},
+				{value: "8", valid: true},
+				{value: "40", valid: true},
+				{value: "-1", valid: false},
+				{value: "3", valid: false},
+				{value: "41", valid: false},
+			},
+		},
+		{
+			name: "Gui.CopyDiffContextLines",
+			setup: func(config *UserConfig, value string) {
+				config.Gui.CopyDiffContextLines, _ = strconv.Atoi(value)
+			},
+			testCases: []testCase{
+				{value: "-1", valid: true},
+				{value: "0", valid: true},
+				{value: "10", valid: true},
+				{value: "-2", valid: false},
+			},
+		},
 		{
 			name: "Keybindings",
 			setup: func(config *UserConfig, value string) {
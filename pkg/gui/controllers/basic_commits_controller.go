@@ -7,9 +7,11 @@ import (
 
 	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/context"
 	"github.com/jesseduffield/lazygit/pkg/gui/keybindings"
 	"github.com/jesseduffield/lazygit/pkg/gui/types"
 	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
 )
 
 // This controller is for all contexts that contain a list of commits.
@@ -59,7 +61,7 @@ func (self *BasicCommitsController) GetKeybindings(opts types.KeybindingsOpts) [
 		{
 			Key:               opts.GetKey(opts.Config.Commits.CopyCommitAttributeToClipboard),
 			Handler:           self.withItem(self.copyCommitAttribute),
-			GetDisabledReason: self.require(self.singleItemSelected()),
+			GetDisabledReason: self.require(self.itemRangeSelected()),
 			Description:       self.c.Tr.CopyCommitAttributeToClipboard,
 			Tooltip:           self.c.Tr.CopyCommitAttributeToClipboardTooltip,
 			OpensMenu:         true,
@@ -190,6 +192,41 @@ func (self *BasicCommitsController) copyCommitAttribute(commit *models.Commit) e
 			},
 			Key: 'a',
 		},
+		{
+			Label: self.c.Tr.CommitFormatPatch,
+			OnPress: func() error {
+				return self.copyFormatPatchToClipboard(commit)
+			},
+			Key: 'p',
+		},
+		{
+			Label: self.c.Tr.CommitDiffStat,
+			OnPress: func() error {
+				return self.copyDiffStatToClipboard(commit)
+			},
+			Key: 'i',
+		},
+		{
+			Label: self.c.Tr.CommitDiffVsWorkingTree,
+			OnPress: func() error {
+				return self.copyDiffVsWorkingTreeToClipboard(commit)
+			},
+			Key: 'w',
+		},
+		{
+			Label: self.c.Tr.CommitTreeFileList,
+			OnPress: func() error {
+				return self.copyTreeFileListToClipboard(commit)
+			},
+			Key: 'f',
+		},
+		{
+			Label: self.c.Tr.CommitDiffForPath,
+			OnPress: func() error {
+				return self.copyCommitDiffForPathToClipboard(commit)
+			},
+			Key: 'l',
+		},
 	}
 
 	commitTagsItem := types.MenuItem{
@@ -206,6 +243,67 @@ func (self *BasicCommitsController) copyCommitAttribute(commit *models.Commit) e
 
 	items = append(items, &commitTagsItem)
 
+	if self.context.GetKey() == context.REFLOG_COMMITS_CONTEXT_KEY {
+		reflogEntryItem := &types.MenuItem{
+			Label: self.c.Tr.CommitReflogEntry,
+			OnPress: func() error {
+				return self.copyReflogEntryToClipboard(commit, self.context.GetSelectedLineIdx())
+			},
+			Key: 'e',
+		}
+		// the entries filtered out would throw off the index in the selector
+		if self.c.Modes().Filtering.Active() {
+			reflogEntryItem.DisabledReason = &types.DisabledReason{Text: self.c.Tr.ReflogEntryNotAvailableWhileFiltering}
+		}
+		items = append(items, reflogEntryItem)
+	}
+
+	// all the other items only make sense for a single commit
+	selectedCommits, _, _ := self.context.GetSelectedItems()
+	isRange := len(selectedCommits) > 1
+	if isRange {
+		for _, item := range items {
+			item.DisabledReason = &types.DisabledReason{Text: self.c.Tr.RangeSelectNotSupported}
+		}
+	}
+
+	rangeDiffItem := &types.MenuItem{
+		Label: self.c.Tr.CommitRangeDiff,
+		OnPress: func() error {
+			// the selection is ordered newest first
+			return self.copyRangeDiffToClipboard(selectedCommits[len(selectedCommits)-1], selectedCommits[0])
+		},
+		Key: 'r',
+	}
+	if !isRange {
+		rangeDiffItem.DisabledReason = &types.DisabledReason{Text: self.c.Tr.RangeDiffRequiresRangeSelection}
+	}
+	items = append(items, rangeDiffItem)
+
+	combinedRangeDiffItem := &types.MenuItem{
+		Label: self.c.Tr.CommitCombinedRangeDiff,
+		OnPress: func() error {
+			return self.copyCombinedRangeDiffToClipboard(selectedCommits[len(selectedCommits)-1], selectedCommits[0])
+		},
+		Key: 'c',
+	}
+	if !isRange {
+		combinedRangeDiffItem.DisabledReason = &types.DisabledReason{Text: self.c.Tr.CombinedRangeDiffRequiresRangeSelection}
+	}
+	items = append(items, combinedRangeDiffItem)
+
+	commitHashesItem := &types.MenuItem{
+		Label: self.c.Tr.CommitHashes,
+		OnPress: func() error {
+			return self.copyCommitHashesToClipboard(selectedCommits)
+		},
+		Key: 'h',
+	}
+	if !isRange {
+		commitHashesItem.DisabledReason = &types.DisabledReason{Text: self.c.Tr.CommitHashesRequireRangeSelection}
+	}
+	items = append(items, commitHashesItem)
+
 	return self.c.Menu(types.CreateMenuOptions{
 		Title: self.c.Tr.Actions.CopyCommitAttributeToClipboard,
 		Items: items,
@@ -238,13 +336,13 @@ func (self *BasicCommitsController) copyCommitURLToClipboard(commit *models.Comm
 }
 
 func (self *BasicCommitsController) copyCommitDiffToClipboard(commit *models.Commit) error {
-	diff, err := self.c.Git().Commit.GetCommitDiff(commit.Hash)
+	diff, err := self.c.Git().Commit.GetCommitDiff(commit.Hash, self.c.Helpers().Diff.CopiedDiffArgs()...)
 	if err != nil {
 		return err
 	}
 
 	self.c.LogAction(self.c.Tr.Actions.CopyCommitDiffToClipboard)
-	if err := self.c.OS().CopyToClipboard(diff); err != nil {
+	if err := self.c.OS().CopyDiffToClipboard(diff); err != nil {
 		return err
 	}
 
@@ -252,6 +350,158 @@ func (self *BasicCommitsController) copyCommitDiffToClipboard(commit *models.Com
 	return nil
 }
 
+func (self *BasicCommitsController) copyCommitDiffForPathToClipboard(commit *models.Commit) error {
+	self.c.Prompt(types.PromptOpts{
+		Title:               self.c.Tr.CommitDiffPathFilterTitle,
+		FindSuggestionsFunc: self.c.Helpers().Suggestions.GetFilePathSuggestionsFunc(),
+		HandleConfirm: func(path string) error {
+			diff, err := self.c.Git().Commit.GetCommitDiffForPath(commit.Hash, path, self.c.Helpers().Diff.CopiedDiffArgs()...)
+			if err != nil {
+				return err
+			}
+			// the commit doesn't touch anything under the path
+			if diff == "" {
+				return errors.New(self.c.Tr.NoContentToCopyError)
+			}
+
+			self.c.LogAction(self.c.Tr.Actions.CopyCommitDiffForPathToClipboard)
+			if err := self.c.OS().CopyDiffToClipboard(diff); err != nil {
+				return err
+			}
+
+			self.c.Toast(self.c.Tr.CommitDiffCopiedToClipboard)
+			return nil
+		},
+	})
+
+	return nil
+}
+
+// copies the changes of the commits from the merge base of the two commits up
+// to the second one, like `git diff from...to`
+func (self *BasicCommitsController) copyRangeDiffToClipboard(from *models.Commit, to *models.Commit) error {
+	diff, err := self.c.Git().Commit.GetRangeDiff(from.Hash, to.Hash, self.c.Helpers().Diff.CopiedDiffArgs()...)
+	if err != nil {
+		return err
+	}
+
+	self.c.LogAction(self.c.Tr.Actions.CopyRangeDiffToClipboard)
+	if err := self.c.OS().CopyDiffToClipboard(diff); err != nil {
+		return err
+	}
+
+	self.c.Toast(self.c.Tr.RangeDiffCopiedToClipboard)
+	return nil
+}
+
+// copies the changes of all the commits from the first one up to the last one
+// as a single diff
+func (self *BasicCommitsController) copyCombinedRangeDiffToClipboard(first *models.Commit, last *models.Commit) error {
+	diff, err := self.c.Git().Commit.GetCombinedRangeDiff(first, last, self.c.Helpers().Diff.CopiedDiffArgs()...)
+	if err != nil {
+		return err
+	}
+
+	self.c.LogAction(self.c.Tr.Actions.CopyCombinedRangeDiffToClipboard)
+	if err := self.c.OS().CopyDiffToClipboard(diff); err != nil {
+		return err
+	}
+
+	self.c.Toast(self.c.Tr.CombinedRangeDiffCopiedToClipboard)
+	return nil
+}
+
+// copies the full hashes of the commits, one per line, in the order they're
+// shown in
+func (self *BasicCommitsController) copyCommitHashesToClipboard(commits []*models.Commit) error {
+	hashes := lo.Map(commits, func(commit *models.Commit, _ int) string { return commit.Hash })
+
+	self.c.LogAction(self.c.Tr.Actions.CopyCommitHashesToClipboard)
+	if err := self.c.OS().CopyToClipboard(strings.Join(hashes, "\n")); err != nil {
+		return err
+	}
+
+	self.c.Toast(self.c.Tr.CommitHashesCopiedToClipboard)
+	return nil
+}
+
+func (self *BasicCommitsController) copyFormatPatchToClipboard(commit *models.Commit) error {
+	patch, err := self.c.Git().Commit.GetFormatPatch(commit.Hash)
+	if err != nil {
+		return err
+	}
+
+	self.c.LogAction(self.c.Tr.Actions.CopyFormatPatchToClipboard)
+	if err := self.c.OS().CopyDiffToClipboard(patch); err != nil {
+		return err
+	}
+
+	self.c.Toast(self.c.Tr.FormatPatchCopiedToClipboard)
+	return nil
+}
+
+func (self *BasicCommitsController) copyDiffStatToClipboard(commit *models.Commit) error {
+	diffStat, err := self.c.Git().Commit.GetDiffStat(commit.Hash)
+	if err != nil {
+		return err
+	}
+
+	self.c.LogAction(self.c.Tr.Actions.CopyDiffStatToClipboard)
+	if err := self.c.OS().CopyToClipboard(diffStat); err != nil {
+		return err
+	}
+
+	self.c.Toast(self.c.Tr.DiffStatCopiedToClipboard)
+	return nil
+}
+
+func (self *BasicCommitsController) copyDiffVsWorkingTreeToClipboard(commit *models.Commit) error {
+	diff, err := self.c.Git().Commit.GetDiffAgainstWorkingTree(commit.Hash, self.c.Helpers().Diff.CopiedDiffArgs()...)
+	if err != nil {
+		return err
+	}
+	// e.g. for the HEAD commit with a clean working tree
+	if diff == "" {
+		return errors.New(self.c.Tr.NoContentToCopyError)
+	}
+
+	self.c.LogAction(self.c.Tr.Actions.CopyDiffVsWorkingTreeToClipboard)
+	if err := self.c.OS().CopyDiffToClipboard(diff); err != nil {
+		return err
+	}
+
+	self.c.Toast(self.c.Tr.DiffVsWorkingTreeCopiedToClipboard)
+	return nil
+}
+
+func (self *BasicCommitsController) copyTreeFileListToClipboard(commit *models.Commit) error {
+	fileList, err := self.c.Git().Commit.GetTreeFileList(commit.Hash)
+	if err != nil {
+		return err
+	}
+
+	self.c.LogAction(self.c.Tr.Actions.CopyTreeFileListToClipboard)
+	if err := self.c.OS().CopyToClipboard(fileList); err != nil {
+		return err
+	}
+
+	self.c.Toast(self.c.Tr.TreeFileListCopiedToClipboard)
+	return nil
+}
+
+// copies the entry like `git reflog` shows it, e.g. "abc1234 HEAD@{3}: commit: message"
+func (self *BasicCommitsController) copyReflogEntryToClipboard(commit *models.Commit, index int) error {
+	entry := fmt.Sprintf("%s HEAD@{%d}: %s", commit.ShortHash(), index, commit.Name)
+
+	self.c.LogAction(self.c.Tr.Actions.CopyReflogEntryToClipboard)
+	if err := self.c.OS().CopyToClipboard(entry); err != nil {
+		return err
+	}
+
+	self.c.Toast(self.c.Tr.ReflogEntryCopiedToClipboard)
+	return nil
+}
+
 func (self *BasicCommitsController) copyAuthorToClipboard(commit *models.Commit) error {
 	author, err := self.c.Git().Commit.GetCommitAuthor(commit.Hash)
 	if err != nil {
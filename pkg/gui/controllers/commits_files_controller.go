@@ -187,24 +187,47 @@ func (self *CommitFilesController) onClickMain(opts gocui.ViewMouseBindingOpts)
 	return self.enterCommitFile(node, types.OnFocusOpts{ClickedWindowName: "main", ClickedViewLineIdx: opts.Y})
 }
 
-func (self *CommitFilesController) copyDiffToClipboard(path string, toastMessage string) error {
-	from, to := self.context().GetFromAndToForDiff()
-	from, reverse := self.c.Modes().Diffing.GetFromAndReverseArgsForDiff(from)
-
-	cmdObj := self.c.Git().WorkingTree.ShowFileDiffCmdObj(from, to, reverse, path, true)
-	diff, err := cmdObj.RunWithOutput()
-	if err != nil {
-		return err
-	}
-	if err := self.c.OS().CopyToClipboard(diff); err != nil {
+func (self *CommitFilesController) copyDiffToClipboard(files []*models.CommitFile, allFiles bool, opts git_commands.FilesDiffOptions, toastMessage string) error {
+	if err := self.c.Helpers().Diff.CopyDiffToClipboardWithOptions(files, self.refRangeForDiff(), allFiles, opts); err != nil {
 		return err
 	}
 	self.c.Toast(toastMessage)
 	return nil
 }
 
+// the range of commits whose files we're showing; for a single commit, From and To are the same
+func (self *CommitFilesController) refRangeForDiff() types.RefRange {
+	if refRange := self.context().GetRefRange(); refRange != nil {
+		return *refRange
+	}
+	ref := self.context().GetRef()
+	return types.RefRange{From: ref, To: ref}
+}
+
+func nodeFiles(node *filetree.CommitFileNode) []*models.CommitFile {
+	return lo.Map(node.GetLeaves(), func(leaf *filetree.Node[models.CommitFile], _ int) *models.CommitFile {
+		return leaf.File
+	})
+}
+
+// the files to pass to the diff helper for the given node. For a directory this
+// is a single entry for the directory itself, so that git gets one pathspec
+// rather than one argument per file underneath it.
+func nodeDiffFiles(node *filetree.CommitFileNode) []*models.CommitFile {
+	if node.IsFile() {
+		return []*models.CommitFile{node.File}
+	}
+	return []*models.CommitFile{{Name: node.GetPath()}}
+}
+
 func (self *CommitFilesController) openCopyMenu() error {
 	node := self.context().GetSelected()
+	isDirectory := node != nil && !node.IsFile()
+
+	pathCopiedToast, diffLabel, diffCopiedToast := self.c.Tr.FilePathCopiedToast, self.c.Tr.CopySelectedDiff, self.c.Tr.FileDiffCopiedToast
+	if isDirectory {
+		pathCopiedToast, diffLabel, diffCopiedToast = self.c.Tr.DirectoryPathCopiedToast, self.c.Tr.CopySelectedDirectoryDiff, self.c.Tr.DirectoryDiffCopiedToast
+	}
 
 	copyNameItem := &types.MenuItem{
 		Label: self.c.Tr.CopyFileName,
@@ -224,28 +247,85 @@ func (self *CommitFilesController) openCopyMenu() error {
 			if err := self.c.OS().CopyToClipboard(node.Path); err != nil {
 				return err
 			}
-			self.c.Toast(self.c.Tr.FilePathCopiedToast)
+			self.c.Toast(pathCopiedToast)
 			return nil
 		},
 		DisabledReason: self.require(self.singleItemSelected())(),
 		Key:            'p',
 	}
 	copyFileDiffItem := &types.MenuItem{
-		Label: self.c.Tr.CopySelectedDiff,
+		Label: diffLabel,
 		OnPress: func() error {
-			return self.copyDiffToClipboard(node.GetPath(), self.c.Tr.FileDiffCopiedToast)
+			// for a directory this gives us the diffs of all files underneath it
+			return self.copyDiffToClipboard(nodeDiffFiles(node), false, git_commands.FilesDiffOptions{}, diffCopiedToast)
 		},
 		DisabledReason: self.require(self.singleItemSelected())(),
 		Key:            's',
 	}
+	copyMarkdownDiffItem := &types.MenuItem{
+		Label: self.c.Tr.CopyMarkdownDiff,
+		OnPress: func() error {
+			if err := self.c.Helpers().Diff.CopyMarkdownDiffToClipboard(nodeDiffFiles(node), self.refRangeForDiff(), false); err != nil {
+				return err
+			}
+			self.c.Toast(self.c.Tr.MarkdownDiffCopiedToast)
+			return nil
+		},
+		DisabledReason: self.require(self.singleItemSelected())(),
+		Key:            'm',
+	}
+	copyWordDiffItem := &types.MenuItem{
+		Label: self.c.Tr.CopyWordDiff,
+		OnPress: func() error {
+			return self.copyDiffToClipboard(nodeDiffFiles(node), false, git_commands.FilesDiffOptions{WordDiff: true}, diffCopiedToast)
+		},
+		DisabledReason: self.require(self.singleItemSelected())(),
+		Key:            'w',
+	}
+	copyIgnoringWhitespaceItem := &types.MenuItem{
+		Label: self.c.Tr.CopyDiffIgnoringWhitespace,
+		OnPress: func() error {
+			return self.copyDiffToClipboard(nodeDiffFiles(node), false, git_commands.FilesDiffOptions{IgnoreWhitespace: true}, diffCopiedToast)
+		},
+		DisabledReason: self.require(self.singleItemSelected())(),
+		Key:            'i',
+	}
+	copyChangedPathsItem := &types.MenuItem{
+		Label: self.c.Tr.CopyChangedFilePaths,
+		OnPress: func() error {
+			selectedNodes, _, _ := self.context().GetSelectedItems()
+			paths := lo.FlatMap(normalisedSelectedCommitFileNodes(selectedNodes), func(node *filetree.CommitFileNode, _ int) []string {
+				return lo.Map(nodeFiles(node), func(file *models.CommitFile, _ int) string {
+					return file.GetPath()
+				})
+			})
+			if err := self.c.OS().CopyToClipboard(strings.Join(paths, "\n")); err != nil {
+				return err
+			}
+			self.c.Toast(self.c.Tr.ChangedFilePathsCopiedToast)
+			return nil
+		},
+		DisabledReason: self.require(self.itemsSelected())(),
+		Key:            'f',
+	}
 	copyAllDiff := &types.MenuItem{
 		Label: self.c.Tr.CopyAllFilesDiff,
 		OnPress: func() error {
-			return self.copyDiffToClipboard(".", self.c.Tr.AllFilesDiffCopiedToast)
+			return self.copyDiffToClipboard(nil, true, git_commands.FilesDiffOptions{}, self.c.Tr.AllFilesDiffCopiedToast)
 		},
 		DisabledReason: self.require(self.itemsSelected())(),
 		Key:            'a',
 	}
+	// A rename can only be detected if both the old and the new path are part
+	// of the diff, so this one always covers all files.
+	copyRenameAwareDiff := &types.MenuItem{
+		Label: self.c.Tr.CopyRenameAwareDiff,
+		OnPress: func() error {
+			return self.copyDiffToClipboard(nil, true, git_commands.FilesDiffOptions{DetectRenames: true}, self.c.Tr.AllFilesDiffCopiedToast)
+		},
+		DisabledReason: self.require(self.itemsSelected())(),
+		Key:            'r',
+	}
 
 	return self.c.Menu(types.CreateMenuOptions{
 		Title: self.c.Tr.CopyToClipboardMenu,
@@ -253,7 +333,12 @@ func (self *CommitFilesController) openCopyMenu() error {
 			copyNameItem,
 			copyPathItem,
 			copyFileDiffItem,
+			copyMarkdownDiffItem,
+			copyWordDiffItem,
+			copyIgnoringWhitespaceItem,
+			copyChangedPathsItem,
 			copyAllDiff,
+			copyRenameAwareDiff,
 		},
 	})
 }
@@ -0,0 +1,37 @@
+package controllers
+
+import (
+	"errors"
+	"strings"
+)
+
+type CopySelectedLineAction struct {
+	c *ControllerCommon
+}
+
+// Copies the text of the focused view's selected line, without any styling.
+func (self *CopySelectedLineAction) Call() error {
+	view := self.c.Context().Current().GetView()
+	if view == nil {
+		return nil
+	}
+
+	lines := view.BufferLines()
+	lineIdx := view.SelectedLineIdx()
+	if lineIdx < 0 || lineIdx >= len(lines) {
+		return errors.New(self.c.Tr.NoContentToCopyError)
+	}
+
+	line := strings.TrimSpace(lines[lineIdx])
+	if line == "" {
+		return errors.New(self.c.Tr.NoContentToCopyError)
+	}
+
+	self.c.LogAction(self.c.Tr.Actions.CopyToClipboard)
+	if err := self.c.OS().CopyToClipboard(line); err != nil {
+		return err
+	}
+
+	self.c.Toast(self.c.Tr.SelectedLineCopiedToClipboard)
+	return nil
+}
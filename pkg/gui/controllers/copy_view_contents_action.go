@@ -0,0 +1,31 @@
+package controllers
+
+import (
+	"errors"
+	"strings"
+)
+
+type CopyViewContentsAction struct {
+	c *ControllerCommon
+}
+
+// Copies all lines of the focused view, without any styling.
+func (self *CopyViewContentsAction) Call() error {
+	view := self.c.Context().Current().GetView()
+	if view == nil {
+		return nil
+	}
+
+	contents := strings.TrimRight(strings.Join(view.BufferLines(), "\n"), "\n ")
+	if strings.TrimSpace(contents) == "" {
+		return errors.New(self.c.Tr.NoContentToCopyError)
+	}
+
+	self.c.LogAction(self.c.Tr.Actions.CopyToClipboard)
+	if err := self.c.OS().CopyToClipboard(contents); err != nil {
+		return err
+	}
+
+	self.c.Toast(self.c.Tr.ViewContentsCopiedToClipboard)
+	return nil
+}
@@ -241,7 +241,7 @@ func (self *CustomPatchOptionsMenuAction) copyPatchToClipboard() error {
 	patch := self.c.Git().Patch.PatchBuilder.RenderAggregatedPatch(true)
 
 	self.c.LogAction(self.c.Tr.Actions.CopyPatchToClipboard)
-	if err := self.c.OS().CopyToClipboard(patch); err != nil {
+	if err := self.c.OS().CopyDiffToClipboard(patch); err != nil {
 		return err
 	}
 
@@ -3,6 +3,8 @@ package controllers
 import (
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/jesseduffield/gocui"
@@ -988,11 +990,12 @@ func (self *FilesController) openCopyMenu() error {
 		OnPress: func() error {
 			path := self.context().GetSelectedPath()
 			hasStaged := self.hasPathStagedChanges(node)
-			diff, err := self.c.Git().Diff.GetDiff(hasStaged, "--", path)
+			diffArgs := append(self.c.Helpers().Diff.CopiedDiffArgs(), "--", path)
+			diff, err := self.c.Git().Diff.GetDiff(hasStaged, diffArgs...)
 			if err != nil {
 				return err
 			}
-			if err := self.c.OS().CopyToClipboard(diff); err != nil {
+			if err := self.c.OS().CopyDiffToClipboard(diff); err != nil {
 				return err
 			}
 			self.c.Toast(self.c.Tr.FileDiffCopiedToast)
@@ -1013,11 +1016,12 @@ func (self *FilesController) openCopyMenu() error {
 		Tooltip: self.c.Tr.CopyFileDiffTooltip,
 		OnPress: func() error {
 			hasStaged := self.c.Helpers().WorkingTree.AnyStagedFiles()
-			diff, err := self.c.Git().Diff.GetDiff(hasStaged, "--")
+			diffArgs := append(self.c.Helpers().Diff.CopiedDiffArgs(), "--")
+			diff, err := self.c.Git().Diff.GetDiff(hasStaged, diffArgs...)
 			if err != nil {
 				return err
 			}
-			if err := self.c.OS().CopyToClipboard(diff); err != nil {
+			if err := self.c.OS().CopyDiffToClipboard(diff); err != nil {
 				return err
 			}
 			self.c.Toast(self.c.Tr.AllFilesDiffCopiedToast)
@@ -1033,6 +1037,30 @@ func (self *FilesController) openCopyMenu() error {
 		)(),
 		Key: 'a',
 	}
+	copyConflictedContentItem := &types.MenuItem{
+		Label: self.c.Tr.CopyConflictedContent,
+		OnPress: func() error {
+			content, err := os.ReadFile(filepath.Join(self.c.Git().RepoPaths.WorktreePath(), node.GetPath()))
+			if err != nil {
+				return err
+			}
+			self.c.LogAction(self.c.Tr.Actions.CopyConflictedContentToClipboard)
+			if err := self.c.OS().CopyToClipboard(string(content)); err != nil {
+				return err
+			}
+			self.c.Toast(self.c.Tr.ConflictedContentCopiedToast)
+			return nil
+		},
+		DisabledReason: self.require(self.singleItemSelected(
+			func(file *filetree.FileNode) *types.DisabledReason {
+				if !file.IsFile() || !file.GetHasInlineMergeConflicts() {
+					return &types.DisabledReason{Text: self.c.Tr.FileHasNoMergeConflicts}
+				}
+				return nil
+			},
+		))(),
+		Key: 'c',
+	}
 
 	return self.c.Menu(types.CreateMenuOptions{
 		Title: self.c.Tr.CopyToClipboardMenu,
@@ -1041,6 +1069,7 @@ func (self *FilesController) openCopyMenu() error {
 			copyPathItem,
 			copyFileDiffItem,
 			copyAllDiff,
+			copyConflictedContentItem,
 		},
 	})
 }
@@ -126,6 +126,18 @@ func (self *GlobalController) GetKeybindings(opts types.KeybindingsOpts) []*type
 			Description: self.c.Tr.ToggleWhitespaceInDiffView,
 			Tooltip:     self.c.Tr.ToggleWhitespaceInDiffViewTooltip,
 		},
+		{
+			Key:         opts.GetKey(opts.Config.Universal.CopySelectedLine),
+			Handler:     self.copySelectedLine,
+			Description: self.c.Tr.CopySelectedLine,
+			Tooltip:     self.c.Tr.CopySelectedLineTooltip,
+		},
+		{
+			Key:         opts.GetKey(opts.Config.Universal.CopyViewContents),
+			Handler:     self.copyViewContents,
+			Description: self.c.Tr.CopyViewContents,
+			Tooltip:     self.c.Tr.CopyViewContentsTooltip,
+		},
 	}
 }
 
@@ -191,3 +203,11 @@ func (self *GlobalController) escape() error {
 func (self *GlobalController) toggleWhitespace() error {
 	return (&ToggleWhitespaceAction{c: self.c}).Call()
 }
+
+func (self *GlobalController) copySelectedLine() error {
+	return (&CopySelectedLineAction{c: self.c}).Call()
+}
+
+func (self *GlobalController) copyViewContents() error {
+	return (&CopyViewContentsAction{c: self.c}).Call()
+}
@@ -1,6 +1,7 @@
 package helpers
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
@@ -143,6 +144,72 @@ func (self *DiffHelper) WithDiffModeCheck(f func()) {
 	}
 }
 
+// CopyDiffToClipboard copies the diff of the given commit files within
+// refRange to the clipboard, or the diff of all files in the range if allFiles
+// is true. A file's path may also be that of a directory, to get the diff of
+// everything underneath it. It doesn't depend on any menu, so it can be
+// triggered from anywhere.
+func (self *DiffHelper) CopyDiffToClipboard(files []*models.CommitFile, refRange types.RefRange, allFiles bool) error {
+	return self.CopyDiffToClipboardWithOptions(files, refRange, allFiles, git_commands.FilesDiffOptions{})
+}
+
+// CopyDiffToClipboardWithOptions is like CopyDiffToClipboard, but lets the
+// caller tweak how the diff is generated, e.g. to detect renames.
+func (self *DiffHelper) CopyDiffToClipboardWithOptions(files []*models.CommitFile, refRange types.RefRange, allFiles bool, opts git_commands.FilesDiffOptions) error {
+	diff, err := self.GetDiffForClipboard(files, refRange, allFiles, opts)
+	if err != nil {
+		return err
+	}
+
+	return self.c.OS().CopyDiffToClipboard(diff)
+}
+
+// CopyMarkdownDiffToClipboard is like CopyDiffToClipboard, but wraps the diff
+// in a fenced code block so that it's highlighted when pasted into markdown.
+func (self *DiffHelper) CopyMarkdownDiffToClipboard(files []*models.CommitFile, refRange types.RefRange, allFiles bool) error {
+	diff, err := self.GetDiffForClipboard(files, refRange, allFiles, git_commands.FilesDiffOptions{})
+	if err != nil {
+		return err
+	}
+
+	return self.c.OS().CopyDiffToClipboard(markdownDiff(diff))
+}
+
+// GetDiffForClipboard returns the plain diff that CopyDiffToClipboard copies.
+func (self *DiffHelper) GetDiffForClipboard(files []*models.CommitFile, refRange types.RefRange, allFiles bool, opts git_commands.FilesDiffOptions) (string, error) {
+	from, reverse := self.c.Modes().Diffing.GetFromAndReverseArgsForDiff(refRange.From.ParentRefName())
+	to := refRange.To.RefName()
+
+	paths := []string{"."}
+	if !allFiles {
+		if len(files) == 0 {
+			return "", nil
+		}
+		paths = lo.Map(files, func(file *models.CommitFile, _ int) string { return file.GetPath() })
+	}
+
+	if contextLines := self.c.UserConfig().Gui.CopyDiffContextLines; contextLines >= 0 && opts.ContextSize == nil {
+		contextSize := uint64(contextLines)
+		opts.ContextSize = &contextSize
+	}
+
+	return self.c.Git().WorkingTree.ShowFilesDiffCmdObj(from, to, reverse, paths, true, opts).RunWithOutput()
+}
+
+// CopiedDiffArgs returns the extra arguments for generating a diff that is
+// going to be copied to the clipboard, as configured by gui.copyDiffContextLines.
+func (self *DiffHelper) CopiedDiffArgs() []string {
+	contextLines := self.c.UserConfig().Gui.CopyDiffContextLines
+	if contextLines < 0 {
+		contextLines = int(self.c.GetAppState().DiffContextSize)
+	}
+	return []string{fmt.Sprintf("--unified=%d", contextLines)}
+}
+
+func markdownDiff(diff string) string {
+	return "```diff\n" + strings.TrimSuffix(diff, "\n") + "\n```"
+}
+
 func (self *DiffHelper) IgnoringWhitespaceSubTitle() string {
 	if self.c.GetAppState().IgnoreWhitespaceInDiffView {
 		return self.c.Tr.IgnoreWhitespaceDiffViewSubTitle
@@ -340,6 +340,13 @@ func (self *RefreshHelper) refreshCommitsWithLimit() error {
 	}
 	self.c.Model().Commits = commits
 	self.RefreshAuthors(commits)
+	if !self.c.Modes().Filtering.Active() && self.c.GetAppState().GitLogShowGraph != "never" {
+		// get the graph ready in the background up to the end of the page
+		// after the one currently shown, so that it's there when the commits
+		// view gets rendered, scrolled or maximised
+		startIdx, length := self.c.Contexts().LocalCommits.GetViewTrait().ViewPortYBounds()
+		presentation.PrecomputePipeSets(commits, startIdx+2*length)
+	}
 	self.c.Model().WorkingTreeStateAtLastCommitRefresh = self.c.Git().Status.WorkingTreeState()
 	self.c.Model().CheckedOutBranch = checkedOutBranchName
 
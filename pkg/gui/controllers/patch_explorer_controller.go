@@ -137,6 +137,12 @@ func (self *PatchExplorerController) GetKeybindings(opts types.KeybindingsOpts)
 			Handler:     self.withLock(self.CopySelectedToClipboard),
 			Description: self.c.Tr.CopySelectedTextToClipboard,
 		},
+		{
+			Key:         opts.GetKey(opts.Config.Main.CopySelectedHunkAsPatch),
+			Handler:     self.withLock(self.CopySelectedHunkAsPatchToClipboard),
+			Description: self.c.Tr.CopySelectedHunkAsPatch,
+			Tooltip:     self.c.Tr.CopySelectedHunkAsPatchTooltip,
+		},
 	}
 }
 
@@ -292,6 +298,18 @@ func (self *PatchExplorerController) CopySelectedToClipboard() error {
 	return nil
 }
 
+func (self *PatchExplorerController) CopySelectedHunkAsPatchToClipboard() error {
+	hunkPatch := self.context.GetState().CurrentHunkAsPatch()
+
+	self.c.LogAction(self.c.Tr.Actions.CopySelectedHunkAsPatch)
+	if err := self.c.OS().CopyDiffToClipboard(hunkPatch); err != nil {
+		return err
+	}
+
+	self.c.Toast(self.c.Tr.HunkPatchCopiedToClipboard)
+	return nil
+}
+
 func (self *PatchExplorerController) isFocused() bool {
 	return self.c.Context().Current().GetKey() == self.context.GetKey()
 }
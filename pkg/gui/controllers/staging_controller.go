@@ -3,12 +3,14 @@ package controllers
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/jesseduffield/gocui"
 	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
 	"github.com/jesseduffield/lazygit/pkg/commands/patch"
 	"github.com/jesseduffield/lazygit/pkg/gui/keybindings"
 	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
 type StagingController struct {
@@ -67,6 +69,12 @@ func (self *StagingController) GetKeybindings(opts types.KeybindingsOpts) []*typ
 			Description: self.c.Tr.EditFile,
 			Tooltip:     self.c.Tr.EditFileTooltip,
 		},
+		{
+			Key:         opts.GetKey(opts.Config.Main.CopyBlameInfo),
+			Handler:     self.CopyBlameInfo,
+			Description: self.c.Tr.CopyBlameInfo,
+			Tooltip:     self.c.Tr.CopyBlameInfoTooltip,
+		},
 		{
 			Key:         opts.GetKey(opts.Config.Universal.Return),
 			Handler:     self.Escape,
@@ -167,6 +175,40 @@ func (self *StagingController) EditFile() error {
 	return self.c.Helpers().Files.EditFileAtLine(path, lineNumber)
 }
 
+// copies e.g. "1a2b3c4d (Jane Doe, 2024-01-31) some code" for the selected line
+func (self *StagingController) CopyBlameInfo() error {
+	self.context.GetMutex().Lock()
+	defer self.context.GetMutex().Unlock()
+
+	path := self.FilePath()
+
+	if path == "" {
+		return nil
+	}
+
+	lineNumber := self.context.GetState().CurrentLineNumber()
+	lineNumber = self.c.Helpers().Diff.AdjustLineNumber(path, lineNumber, self.context.GetViewName())
+	info, err := self.c.Git().Blame.BlameWorkingTreeLine(path, lineNumber)
+	if err != nil {
+		return err
+	}
+
+	blameInfo := fmt.Sprintf("%s (%s, %s) %s",
+		utils.ShortHash(info.Hash),
+		info.AuthorName,
+		time.Unix(info.UnixTimestamp, 0).Format(time.DateOnly),
+		info.Line,
+	)
+
+	self.c.LogAction(self.c.Tr.Actions.CopyBlameInfoToClipboard)
+	if err := self.c.OS().CopyToClipboard(blameInfo); err != nil {
+		return err
+	}
+
+	self.c.Toast(self.c.Tr.BlameInfoCopiedToClipboard)
+	return nil
+}
+
 func (self *StagingController) Escape() error {
 	if self.context.GetState().SelectingRange() || self.context.GetState().SelectingHunk() {
 		self.context.GetState().SetLineSelectMode()
@@ -69,6 +69,13 @@ func (self *StashController) GetKeybindings(opts types.KeybindingsOpts) []*types
 			GetDisabledReason: self.require(self.singleItemSelected()),
 			Description:       self.c.Tr.RenameStash,
 		},
+		{
+			Key:               opts.GetKey(opts.Config.Universal.CopyToClipboard),
+			Handler:           self.withItem(self.handleCopyStashDiff),
+			GetDisabledReason: self.require(self.singleItemSelected()),
+			Description:       self.c.Tr.CopyStashDiff,
+			Tooltip:           self.c.Tr.CopyStashDiffTooltip,
+		},
 	}
 
 	return bindings
@@ -187,6 +194,21 @@ func (self *StashController) handleNewBranchOffStashEntry(stashEntry *models.Sta
 	return self.c.Helpers().Refs.NewBranch(stashEntry.RefName(), stashEntry.Description(), "")
 }
 
+func (self *StashController) handleCopyStashDiff(stashEntry *models.StashEntry) error {
+	diff, err := self.c.Git().Stash.GetStashDiff(stashEntry.Index, self.c.Helpers().Diff.CopiedDiffArgs()...)
+	if err != nil {
+		return err
+	}
+
+	self.c.LogAction(self.c.Tr.Actions.CopyStashDiffToClipboard)
+	if err := self.c.OS().CopyDiffToClipboard(diff); err != nil {
+		return err
+	}
+
+	self.c.Toast(self.c.Tr.StashDiffCopiedToClipboard)
+	return nil
+}
+
 func (self *StashController) handleRenameStashEntry(stashEntry *models.StashEntry) error {
 	message := utils.ResolvePlaceholderString(
 		self.c.Tr.RenameStashPrompt,
@@ -726,8 +726,22 @@ func NewGui(
 
 var RuneReplacements = map[rune]string{
 	// for the commit graph
-	graph.MergeSymbol:  "M",
-	graph.CommitSymbol: "o",
+	graph.MergeSymbol:          "M",
+	graph.CommitSymbol:         "o",
+	graph.StashSymbol:          "S",
+	graph.GraftedSymbol:        "G",
+	graph.WIPSymbol:            "W",
+	graph.CollapsedSymbol:      "C",
+	graph.EmptySymbol:          "e",
+	graph.FoldedSymbol:         "f",
+	graph.JunctionSymbol:       "M",
+	graph.MediumCommitSymbol:   "o",
+	graph.HeavyCommitSymbol:    "O",
+	graph.OffScreenSymbol:      "v",
+	graph.SpilloverSymbol:      "~",
+	graph.ArrowheadLeftSymbol:  "<",
+	graph.ArrowheadRightSymbol: ">",
+	graph.AnnotatedTagSymbol:   "@",
 }
 
 func (gui *Gui) initGocui(headless bool, test integrationTypes.IntegrationTest) (*gocui.Gui, error) {
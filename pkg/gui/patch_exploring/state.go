@@ -305,6 +305,15 @@ func (s *State) PlainRenderSelected() string {
 	return s.patch.FormatRangePlain(firstLineIdx, lastLineIdx)
 }
 
+// returns the hunk containing the selected line as a patch that can be
+// applied with `git apply`, including the file header
+func (s *State) CurrentHunkAsPatch() string {
+	start, end := s.CurrentHunkBounds()
+	return s.patch.Transform(patch.TransformOpts{
+		IncludedLineIndices: patch.ExpandRange(start, end),
+	}).FormatPlain()
+}
+
 func (s *State) SelectBottom() {
 	s.DismissHunkSelectMode()
 	s.SelectLine(len(s.patchLineIndices) - 1)
@@ -73,6 +73,18 @@ func AuthorWithLength(authorName string, length int) string {
 	return LongAuthor(authorName, length)
 }
 
+// CoAuthorsBadge returns a compact badge listing the initials of the given
+// co-authors, e.g. "+JS+JD", or an empty string if there are none
+func CoAuthorsBadge(coAuthors []string) string {
+	var builder strings.Builder
+	for _, coAuthor := range coAuthors {
+		if initials := ShortAuthor(coAuthor); initials != "" {
+			builder.WriteString("+" + initials)
+		}
+	}
+	return builder.String()
+}
+
 func AuthorStyle(authorName string) style.TextStyle {
 	if value, ok := authorStyleCache[authorName]; ok {
 		return value
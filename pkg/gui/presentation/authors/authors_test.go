@@ -3,8 +3,10 @@ package authors
 import (
 	"testing"
 
+	"github.com/gookit/color"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/xo/terminfo"
 )
 
 func TestGetInitials(t *testing.T) {
@@ -41,3 +43,18 @@ func TestAuthorWithLength(t *testing.T) {
 		assert.Equal(t, s.expectedOutput, utils.Decolorise(AuthorWithLength(s.authorName, s.length)))
 	}
 }
+
+func TestCoAuthorsBadge(t *testing.T) {
+	assert.Equal(t, "", CoAuthorsBadge(nil))
+	assert.Equal(t, "+JS+JD", utils.Decolorise(CoAuthorsBadge([]string{"Jane Smith", "", "John Doe"})))
+}
+
+func TestTrueColorStyleIsStable(t *testing.T) {
+	oldColorLevel := color.ForceSetColorLevel(terminfo.ColorLevelMillions)
+	defer color.ForceSetColorLevel(oldColorLevel)
+
+	// the color only depends on the name, so it's the same across runs
+	assert.Equal(t, trueColorStyle("Jesse Duffield"), trueColorStyle("Jesse Duffield"))
+	assert.Equal(t, "\x1b[38;2;47;228;2mx\x1b[0m", trueColorStyle("Jesse Duffield").Sprint("x"))
+	assert.NotEqual(t, trueColorStyle("Jesse Duffield").Sprint("x"), trueColorStyle("John Doe").Sprint("x"))
+}
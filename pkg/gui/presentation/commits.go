@@ -3,6 +3,7 @@ package presentation
 import (
 	"fmt"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/jesseduffield/generics/set"
@@ -30,6 +31,9 @@ type pipeSetCacheKey struct {
 var (
 	pipeSetCache = make(map[pipeSetCacheKey][][]*graph.Pipe)
 	mutex        deadlock.Mutex
+	// bumped by each call to PrecomputePipeSets so that a precompute can tell
+	// that it's been superseded by a later one
+	precomputeGeneration atomic.Int64
 )
 
 type bisectBounds struct {
@@ -253,28 +257,105 @@ func indexOfFirstNonTODOCommit(commits []*models.Commit) int {
 }
 
 func loadPipesets(commits []*models.Commit) [][]*graph.Pipe {
-	// given that our cache key is a commit hash and a commit count, it's very important that we don't actually try to render pipes
-	// when dealing with things like filtered commits.
-	cacheKey := pipeSetCacheKey{
-		commitHash:  commits[0].Hash,
-		commitCount: len(commits),
-		divergence:  commits[0].Divergence,
-	}
+	cacheKey := getPipeSetCacheKey(commits)
 
 	pipeSets, ok := pipeSetCache[cacheKey]
-	if !ok {
+	// a precompute may have only cached the pipe sets of the first rows
+	if !ok || len(pipeSets) < len(commits) {
 		// pipe sets are unique to a commit head. and a commit count. Sometimes we haven't loaded everything for that.
 		// so let's just cache it based on that.
-		getStyle := func(commit *models.Commit) style.TextStyle {
-			return authors.AuthorStyle(commit.AuthorName)
-		}
-		pipeSets = graph.GetPipeSets(commits, getStyle)
+		pipeSets = graph.GetPipeSets(commits, getPipeSetStyle)
 		pipeSetCache[cacheKey] = pipeSets
 	}
 
 	return pipeSets
 }
 
+// PrecomputePipeSets computes the pipe sets of the first rowCount of the given
+// commits on a background goroutine and stores them in the cache, so that the
+// graph is ready by the time the commits get rendered. Rebase TODO commits at
+// the top are skipped, like when rendering. It's fine to call this repeatedly:
+// a call supersedes any earlier one that is still running, which then stops at
+// the next row without caching anything. The returned channel is closed once
+// the precompute is done or stopped.
+func PrecomputePipeSets(commits []*models.Commit, rowCount int) <-chan struct{} {
+	done := make(chan struct{})
+
+	generation := precomputeGeneration.Add(1)
+
+	if len(commits) == 0 || rowCount <= 0 {
+		close(done)
+		return done
+	}
+
+	commits = commits[indexOfFirstNonTODOCommit(commits):]
+	rowCount = min(rowCount, len(commits))
+	cacheKey := getPipeSetCacheKey(commits)
+	isSuperseded := func() bool { return generation != precomputeGeneration.Load() }
+
+	go utils.Safe(func() {
+		defer close(done)
+
+		if isSuperseded() {
+			return
+		}
+
+		// the lock is only held for accessing the caches, so that rendering
+		// doesn't have to wait for the precompute. The author styles are
+		// looked up beforehand because their cache isn't synchronised.
+		mutex.Lock()
+		if cached, ok := pipeSetCache[cacheKey]; ok && len(cached) >= rowCount {
+			mutex.Unlock()
+			return
+		}
+		authorStyles := map[string]style.TextStyle{}
+		for _, commit := range commits[:rowCount] {
+			authorStyles[commit.AuthorName] = getPipeSetStyle(commit)
+		}
+		mutex.Unlock()
+
+		getStyle := func(commit *models.Commit) style.TextStyle {
+			return authorStyles[commit.AuthorName]
+		}
+
+		pipeSets := make([][]*graph.Pipe, 0, rowCount)
+		stopped := false
+		graph.EachPipeSet(commits, getStyle, func(_ int, pipes []*graph.Pipe) bool {
+			if isSuperseded() {
+				stopped = true
+				return false
+			}
+			pipeSets = append(pipeSets, pipes)
+			return len(pipeSets) < rowCount
+		})
+		if stopped {
+			return
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		if cached, ok := pipeSetCache[cacheKey]; !ok || len(cached) < len(pipeSets) {
+			pipeSetCache[cacheKey] = pipeSets
+		}
+	})
+
+	return done
+}
+
+// given that our cache key is a commit hash and a commit count, it's very important that we don't actually try to render pipes
+// when dealing with things like filtered commits.
+func getPipeSetCacheKey(commits []*models.Commit) pipeSetCacheKey {
+	return pipeSetCacheKey{
+		commitHash:  commits[0].Hash,
+		commitCount: len(commits),
+		divergence:  commits[0].Divergence,
+	}
+}
+
+func getPipeSetStyle(commit *models.Commit) style.TextStyle {
+	return authors.AuthorStyle(commit.AuthorName)
+}
+
 // similar to the git_commands.BisectStatus but more gui-focused
 type BisectStatus int
 
@@ -10,6 +10,7 @@ import (
 	"github.com/jesseduffield/generics/set"
 	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/presentation/graph"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 	"github.com/stefanhaller/git-todo-parser/todo"
 	"github.com/stretchr/testify/assert"
@@ -608,3 +609,59 @@ func TestGetCommitListDisplayStrings(t *testing.T) {
 		}
 	}
 }
+
+func TestPrecomputePipeSets(t *testing.T) {
+	t.Cleanup(func() {
+		mutex.Lock()
+		defer mutex.Unlock()
+		pipeSetCache = make(map[pipeSetCacheKey][][]*graph.Pipe)
+	})
+
+	commits := []*models.Commit{
+		{Hash: "precompute1", Parents: []string{"precompute2"}},
+		{Hash: "precompute2", Parents: []string{"precompute3"}},
+		{Hash: "precompute3"},
+	}
+	otherCommits := []*models.Commit{
+		{Hash: "precompute4", Parents: []string{"precompute5"}},
+		{Hash: "precompute5"},
+	}
+
+	cachedRows := func(commits []*models.Commit) int {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return len(pipeSetCache[getPipeSetCacheKey(commits)])
+	}
+
+	// only the requested rows are computed
+	<-PrecomputePipeSets(commits, 2)
+	assert.Equal(t, 2, cachedRows(commits))
+
+	// asking for more rows than there are commits computes all of them
+	<-PrecomputePipeSets(commits, 10)
+	assert.Equal(t, 3, cachedRows(commits))
+
+	// asking for fewer rows than are cached is a no-op
+	<-PrecomputePipeSets(commits, 1)
+	assert.Equal(t, 3, cachedRows(commits))
+
+	// a precompute that is superseded before it's done stops without caching
+	// anything
+	mutex.Lock()
+	superseded := PrecomputePipeSets(otherCommits, 10)
+	<-PrecomputePipeSets(nil, 10)
+	mutex.Unlock()
+	<-superseded
+	assert.Equal(t, 0, cachedRows(otherCommits))
+
+	// rebase TODO commits are skipped
+	todoCommits := append([]*models.Commit{{Hash: "precompute0", Action: todo.Pick}}, otherCommits...)
+	<-PrecomputePipeSets(todoCommits, 1)
+	assert.Equal(t, 1, cachedRows(otherCommits))
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	// rendering completes partially precomputed pipe sets
+	assert.Equal(t, graph.GetPipeSets(otherCommits, getPipeSetStyle), loadPipesets(otherCommits))
+	assert.Equal(t, graph.GetPipeSets(commits, getPipeSetStyle), pipeSetCache[getPipeSetCacheKey(commits)])
+}
@@ -0,0 +1,106 @@
+package graph
+
+import (
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
+)
+
+const brailleBase = 0x2800
+
+// the dots of a braille character; index 0 is the upper half, 1 the lower half
+var (
+	brailleUpperLeftDot  = [2]rune{0x01, 0x04}
+	brailleLowerLeftDot  = [2]rune{0x02, 0x40}
+	brailleUpperRightDot = [2]rune{0x08, 0x20}
+	brailleLowerRightDot = [2]rune{0x10, 0x80}
+)
+
+// In braille mode, each line packs two rows of the graph: the upper half of a
+// braille character draws the first row and the lower half the second one.
+// Within a half, the left column of dots is the pipe going through the lane
+// and both columns together make up a commit's dot.
+//
+// Braille can only draw straight pipes, so a pair of rows where a pipe changes
+// lanes (e.g. because of a merge) falls back to being rendered normally, as
+// two lines. Each lane still takes two characters so that braille and normal
+// lines line up.
+func renderBraille(pipeSets [][]*Pipe, commits []*models.Commit, selectedCommitHash string, opts *Options) []string {
+	commits = opts.withWIPCommit(commits)
+	opts.computePatchEquivalentStyles(commits)
+	opts.computeAuthorStyles(commits)
+	opts.computeCoAuthorsBadges(commits)
+
+	lines := make([]string, 0, (len(pipeSets)+1)/2)
+	for i := 0; i < len(pipeSets); i += 2 {
+		end := min(i+2, len(pipeSets))
+		if !lo.EveryBy(pipeSets[i:end], isStraightPipeSet) {
+			for k := i; k < end; k++ {
+				row := rowContext{commit: commits[k], index: k, isLast: k == len(pipeSets)-1}
+				if k > 0 {
+					row.prevCommit = commits[k-1]
+				}
+				lines = append(lines, renderPipeSetWithOptions(pipeSets[k], selectedCommitHash, row, opts))
+			}
+			continue
+		}
+
+		lines = append(lines, renderBraillePair(pipeSets[i:end], commits[i:end], selectedCommitHash))
+	}
+
+	return lines
+}
+
+// a pipe set can be drawn in braille if no pipe changes lanes and there's at
+// most one pipe starting (i.e. it's not a merge)
+func isStraightPipeSet(pipes []*Pipe) bool {
+	return lo.EveryBy(pipes, func(pipe *Pipe) bool { return pipe.fromPos == pipe.toPos }) &&
+		lo.CountBy(pipes, func(pipe *Pipe) bool { return pipe.kind == STARTS }) <= 1
+}
+
+func renderBraillePair(pipeSets [][]*Pipe, commits []*models.Commit, selectedCommitHash string) string {
+	width := lo.Max(lo.Map(pipeSets, func(pipes []*Pipe, _ int) int {
+		return lo.Max(lo.Map(pipes, func(pipe *Pipe, _ int) int { return pipe.toPos })) + 1
+	}))
+
+	dots := make([]rune, width)
+	styles := make([]*style.TextStyle, width)
+	for half, pipes := range pipeSets {
+		for _, pipe := range pipes {
+			pos := pipe.toPos
+			textStyle := pipe.style
+			if isInvisible(textStyle) {
+				continue
+			}
+
+			switch pipe.kind {
+			case TERMINATES:
+				dots[pos] |= brailleUpperLeftDot[half]
+			case CONTINUES:
+				dots[pos] |= brailleUpperLeftDot[half] | brailleLowerLeftDot[half]
+			case STARTS:
+				dots[pos] |= brailleUpperLeftDot[half] | brailleLowerLeftDot[half] |
+					brailleUpperRightDot[half] | brailleLowerRightDot[half]
+				if utils.EqualHashes(commits[half].Hash, selectedCommitHash) {
+					textStyle = highlightStyle
+				}
+			}
+			// the lower half wins, as it's closer to what comes next
+			styles[pos] = &textStyle
+		}
+	}
+
+	writer := &strings.Builder{}
+	for pos, dot := range dots {
+		if dot == 0 {
+			writer.WriteString("  ")
+			continue
+		}
+		writer.WriteString(cachedSprint(*styles[pos], string(brailleBase+dot)))
+		writer.WriteString(" ")
+	}
+	return writer.String()
+}
@@ -0,0 +1,47 @@
+package graph
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+)
+
+// AssignBranchColors splits the commits into branch lineages and gives each
+// one a color from the palette, in turn. A lineage starts at a tip (a commit
+// that isn't the first parent of any commit above it) and follows first
+// parents down from there. The returned map has an entry for every commit,
+// holding the color of the lineage it belongs to, so it can back a getStyle
+// callback. Given the same commits, the assignment is always the same; and as
+// the colors are handed out top-down, loading more commits at the bottom
+// doesn't change the colors of those already shown.
+func AssignBranchColors(commits []*models.Commit, palette []style.TextStyle) map[string]style.TextStyle {
+	colors := make(map[string]style.TextStyle, len(commits))
+	if len(palette) == 0 {
+		return colors
+	}
+
+	// maps each commit to the index of its lineage, in order of appearance of
+	// the tips. When several children have the same first parent (e.g. a topic
+	// branch forked off main), the lineage that started higher up wins.
+	lineages := make(map[string]int, len(commits))
+	lineageCount := 0
+	for _, commit := range commits {
+		lineage, ok := lineages[commit.Hash]
+		if !ok {
+			lineage = lineageCount
+			lineageCount++
+			lineages[commit.Hash] = lineage
+		}
+
+		if len(commit.Parents) > 0 {
+			if parentLineage, ok := lineages[commit.Parents[0]]; !ok || lineage < parentLineage {
+				lineages[commit.Parents[0]] = lineage
+			}
+		}
+	}
+
+	for hash, lineage := range lineages {
+		colors[hash] = palette[lineage%len(palette)]
+	}
+
+	return colors
+}
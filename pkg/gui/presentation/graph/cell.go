@@ -2,23 +2,56 @@ package graph
 
 import (
 	"io"
+	"strconv"
 	"sync"
+	"unicode/utf8"
 
 	"github.com/gookit/color"
 	"github.com/jesseduffield/lazygit/pkg/gui/style"
 )
 
 const (
-	MergeSymbol  = '⏣'
-	CommitSymbol = '◯'
+	MergeSymbol     = '⏣'
+	CommitSymbol    = '◯'
+	StashSymbol     = '◈'
+	GraftedSymbol   = '◎'
+	WIPSymbol       = '◌'
+	CollapsedSymbol = '◉'
+	EmptySymbol     = '◦'
+	// for commits that a rebase will squash or fix up into the one below them
+	// (see Options.RebaseActions)
+	FoldedSymbol = '◒'
+	// replaces MergeSymbol if Options.JunctionGlyphs is set
+	JunctionSymbol = '◆'
+	// drawn where the last commit's pipes to its parents leave the rendered
+	// window (see Options.OffScreenParents)
+	OffScreenSymbol = '↓'
+	// drawn in the last lane when lanes beyond Options.MaxLanes have been
+	// spilled over into it, followed by their number
+	SpilloverSymbol = '⋯'
+	// drawn next to a merge's dot on the pipes to its second and further
+	// parents (see Options.MergeArrowheads), pointing at the dot
+	ArrowheadLeftSymbol  = '◂'
+	ArrowheadRightSymbol = '▸'
+	// heavier variants of CommitSymbol, for commits with many changes
+	MediumCommitSymbol = '◍'
+	HeavyCommitSymbol  = '●'
 )
 
+var commitSymbolsByWeight = []string{string(CommitSymbol), string(MediumCommitSymbol), string(HeavyCommitSymbol)}
+
 type cellType int
 
 const (
 	CONNECTION cellType = iota
 	COMMIT
 	MERGE
+	STASH
+	GRAFTED
+	WIP
+	COLLAPSED
+	EMPTY
+	FOLDED
 )
 
 type Cell struct {
@@ -26,9 +59,26 @@ type Cell struct {
 	cellType              cellType
 	rightStyle            *style.TextStyle
 	style                 style.TextStyle
+	// applied on top of the other styles, including to blank space
+	background *style.TextStyle
+	// a dashed cell is drawn faded, with a dashed vertical line
+	dashed bool
+	// for a COMMIT cell, an index into commitSymbolsByWeight
+	weight int
+	// for a COMMIT cell, the glyph to draw instead (see Options.DotGlyph)
+	glyph rune
+	// an otherwise empty cell drawn as a faint vertical line to help the eye
+	// follow a column (see Options.GuideInterval)
+	guide bool
+	// a pipe leaves this cell downward to a parent that isn't rendered
+	offScreen bool
+	// the number of further lanes spilled over into this cell's lane
+	spillover int
+	// drawn instead of the horizontal pipe leaving this cell to the right
+	arrowhead rune
 }
 
-func (cell *Cell) render(writer io.StringWriter) {
+func (cell *Cell) render(writer io.StringWriter, opts *Options) {
 	up, down, left, right := cell.up, cell.down, cell.left, cell.right
 
 	first, second := getBoxDrawingChars(up, down, left, right)
@@ -36,10 +86,91 @@ func (cell *Cell) render(writer io.StringWriter) {
 	switch cell.cellType {
 	case CONNECTION:
 		adjustedFirst = first
+		if cell.dashed && first == "│" {
+			adjustedFirst = "╎"
+		}
+		if cell.guide && first == " " {
+			adjustedFirst = "┊"
+		}
+		if opts.CornerStyle == SharpCorners {
+			adjustedFirst = sharpCorner(adjustedFirst)
+		}
+		if cell.offScreen {
+			adjustedFirst = string(OffScreenSymbol)
+		}
+		if cell.spillover > 0 {
+			adjustedFirst = string(SpilloverSymbol)
+		}
 	case COMMIT:
-		adjustedFirst = string(CommitSymbol)
+		adjustedFirst = commitSymbolsByWeight[cell.weight]
+		if cell.glyph != 0 && (!opts.NonInteractive || cell.glyph < utf8.RuneSelf) {
+			adjustedFirst = string(cell.glyph)
+		}
 	case MERGE:
 		adjustedFirst = string(MergeSymbol)
+		if opts.JunctionGlyphs {
+			adjustedFirst = string(JunctionSymbol)
+		}
+	case STASH:
+		adjustedFirst = string(StashSymbol)
+	case GRAFTED:
+		adjustedFirst = string(GraftedSymbol)
+	case WIP:
+		adjustedFirst = string(WIPSymbol)
+	case COLLAPSED:
+		adjustedFirst = string(CollapsedSymbol)
+	case EMPTY:
+		adjustedFirst = string(EmptySymbol)
+	case FOLDED:
+		adjustedFirst = string(FoldedSymbol)
+	}
+	// the dot takes up the commit's own column, so the arrow goes next to it
+	if cell.offScreen && cell.cellType != CONNECTION && second == " " {
+		second = string(OffScreenSymbol)
+	}
+	// the spillover lane is the last one, so there's nothing to the right of it
+	// that we'd be hiding
+	if cell.spillover > 0 {
+		second = spilloverCount(cell.spillover)
+	}
+	if cell.arrowhead != 0 && second == "─" {
+		second = string(cell.arrowhead)
+	}
+
+	if opts.NonInteractive {
+		_, _ = writer.WriteString(asciiChar(adjustedFirst))
+		_, _ = writer.WriteString(asciiChar(second))
+		return
+	}
+
+	_, _ = writer.WriteString(cell.sprint(cell.style, adjustedFirst))
+	_, _ = writer.WriteString(cell.styledSecondChar(second))
+}
+
+// renders the filler cells that go between this cell and the next one when
+// there's a column gap. A filler cell just continues whatever horizontal pipe
+// leaves this cell to the right, so that pipes remain unbroken.
+func (cell *Cell) renderFiller(writer io.StringWriter, count int, opts *Options) {
+	if count <= 0 {
+		return
+	}
+
+	_, second := getBoxDrawingChars(cell.up, cell.down, cell.left, cell.right)
+	styledSecondChar := cell.styledSecondChar(second)
+	if opts.NonInteractive {
+		styledSecondChar = asciiChar(second)
+	}
+	for i := 0; i < count*2; i++ {
+		_, _ = writer.WriteString(styledSecondChar)
+	}
+}
+
+func (cell *Cell) styledSecondChar(second string) string {
+	// just doing this for the sake of easy testing, so that we don't need to
+	// assert on the style of a space given a space has no styling (assuming we
+	// stick to only using foreground styles)
+	if second == " " && cell.background == nil {
+		return " "
 	}
 
 	var rightStyle *style.TextStyle
@@ -49,18 +180,80 @@ func (cell *Cell) render(writer io.StringWriter) {
 		rightStyle = cell.rightStyle
 	}
 
-	// just doing this for the sake of easy testing, so that we don't need to
-	// assert on the style of a space given a space has no styling (assuming we
-	// stick to only using foreground styles)
-	var styledSecondChar string
-	if second == " " {
-		styledSecondChar = " "
-	} else {
-		styledSecondChar = cachedSprint(*rightStyle, second)
+	return cell.sprint(*rightStyle, second)
+}
+
+func (cell *Cell) sprint(textStyle style.TextStyle, str string) string {
+	if cell.background == nil {
+		return cachedSprint(textStyle, str)
 	}
 
-	_, _ = writer.WriteString(cachedSprint(cell.style, adjustedFirst))
-	_, _ = writer.WriteString(styledSecondChar)
+	// not using the cache here: merging creates a new underlying style each
+	// time, so we'd never get a cache hit
+	return textStyle.MergeStyle(*cell.background).Sprint(str)
+}
+
+// used when the graph is not going to a terminal, e.g. when it's piped into a file
+var asciiReplacements = map[string]string{
+	string(CommitSymbol):         "o",
+	string(MergeSymbol):          "M",
+	string(StashSymbol):          "S",
+	string(GraftedSymbol):        "G",
+	string(WIPSymbol):            "W",
+	string(CollapsedSymbol):      "C",
+	string(EmptySymbol):          "e",
+	string(FoldedSymbol):         "f",
+	string(JunctionSymbol):       "M",
+	string(MediumCommitSymbol):   "o",
+	string(HeavyCommitSymbol):    "O",
+	string(OffScreenSymbol):      "v",
+	string(SpilloverSymbol):      "~",
+	string(ArrowheadLeftSymbol):  "<",
+	string(ArrowheadRightSymbol): ">",
+	string(AnnotatedTagSymbol):   "@",
+	"│":                          "|",
+	"─":                          "-",
+	"┴":                          "+",
+	"┬":                          "+",
+	"╭":                          ".",
+	"╮":                          ".",
+	"╰":                          "'",
+	"╯":                          "'",
+	"┌":                          ".",
+	"┐":                          ".",
+	"└":                          "'",
+	"┘":                          "'",
+	"╵":                          "|",
+	"╷":                          "|",
+	"╶":                          "-",
+	"╎":                          ":",
+	"┊":                          ":",
+}
+
+var (
+	dashedStyle = style.FgBlackLighter
+	guideStyle  = style.FgBlack
+)
+
+var sharpCorners = map[string]string{
+	"╭": "┌",
+	"╮": "┐",
+	"╰": "└",
+	"╯": "┘",
+}
+
+func sharpCorner(str string) string {
+	if replacement, ok := sharpCorners[str]; ok {
+		return replacement
+	}
+	return str
+}
+
+func asciiChar(str string) string {
+	if replacement, ok := asciiReplacements[str]; ok {
+		return replacement
+	}
+	return str
 }
 
 type rgbCacheKey struct {
@@ -139,6 +332,73 @@ func (cell *Cell) setStyle(style style.TextStyle) *Cell {
 	return cell
 }
 
+func (cell *Cell) setBackground(background style.TextStyle) *Cell {
+	cell.background = &background
+	return cell
+}
+
+func (cell *Cell) toBasicColors() *Cell {
+	cell.style = cell.style.ToBasicColors()
+	if cell.rightStyle != nil {
+		rightStyle := cell.rightStyle.ToBasicColors()
+		cell.rightStyle = &rightStyle
+	}
+	if cell.background != nil {
+		background := cell.background.ToBasicColors()
+		cell.background = &background
+	}
+	return cell
+}
+
+func (cell *Cell) setDashed() *Cell {
+	cell.dashed = true
+	cell.style = dashedStyle
+	return cell
+}
+
+func (cell *Cell) setArrowhead(arrowhead rune) *Cell {
+	cell.arrowhead = arrowhead
+	return cell
+}
+
+func (cell *Cell) setOffScreen() *Cell {
+	cell.offScreen = true
+	return cell
+}
+
+func (cell *Cell) setSpillover(count int) *Cell {
+	cell.spillover = count
+	return cell
+}
+
+// the count has to fit into a single character
+func spilloverCount(count int) string {
+	if count > 9 {
+		return "+"
+	}
+	return strconv.Itoa(count)
+}
+
+func (cell *Cell) isEmpty() bool {
+	return cell.cellType == CONNECTION && !cell.up && !cell.down && !cell.left && !cell.right
+}
+
+func (cell *Cell) setGuide() *Cell {
+	cell.guide = true
+	cell.style = guideStyle
+	return cell
+}
+
+func (cell *Cell) setWeight(weight int) *Cell {
+	cell.weight = weight
+	return cell
+}
+
+func (cell *Cell) setGlyph(glyph rune) *Cell {
+	cell.glyph = glyph
+	return cell
+}
+
 func (cell *Cell) setType(cellType cellType) *Cell {
 	cell.cellType = cellType
 	return cell
@@ -0,0 +1,121 @@
+package graph
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/jesseduffield/generics/set"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/samber/lo"
+)
+
+// TopicCollapser collapses the topic branches that have been merged in, so
+// that each one takes up a single row rather than a lane of its own. It
+// remembers which merges the user has expanded again.
+type TopicCollapser struct {
+	expandedMerges *set.Set[string]
+}
+
+func NewTopicCollapser() *TopicCollapser {
+	return &TopicCollapser{expandedMerges: set.New[string]()}
+}
+
+// Toggle expands the topic branch of the given merge if it's collapsed, and
+// collapses it if it's expanded.
+func (self *TopicCollapser) Toggle(mergeHash string) {
+	if self.expandedMerges.Includes(mergeHash) {
+		self.expandedMerges.Remove(mergeHash)
+	} else {
+		self.expandedMerges.Add(mergeHash)
+	}
+}
+
+func (self *TopicCollapser) IsExpanded(mergeHash string) bool {
+	return self.expandedMerges.Includes(mergeHash)
+}
+
+// Collapse is meant to be run on the commits before passing them to
+// GetPipeSets. For each merge that isn't expanded, the commits of its topic
+// branch (those reachable from its second parent but not from its first one,
+// i.e. down to the merge base) are replaced with a single row, standing in for
+// the tip of the topic branch and pointing to the topic branch's parents.
+//
+// It returns the new commits, along with the commits hidden behind each
+// collapsed row, keyed by the hash of that row. Pass those keys as
+// Options.CollapsedHashes to render the rows with a distinct dot.
+func (self *TopicCollapser) Collapse(commits []*models.Commit) ([]*models.Commit, map[string][]*models.Commit) {
+	commitsByHash := lo.SliceToMap(commits, func(commit *models.Commit) (string, *models.Commit) {
+		return commit.Hash, commit
+	})
+
+	hidden := set.New[string]()
+	collapsedRows := map[string]*models.Commit{}
+	hiddenCommits := map[string][]*models.Commit{}
+
+	for _, commit := range commits {
+		if !commit.IsMerge() || hidden.Includes(commit.Hash) || self.IsExpanded(commit.Hash) {
+			continue
+		}
+
+		mainline := reachableCommits(commitsByHash, commit.Parents[:1], nil)
+		topic := reachableCommits(commitsByHash, commit.Parents[1:2], func(hash string) bool {
+			return mainline.Includes(hash) || hidden.Includes(hash)
+		})
+		topicCommits := lo.Filter(commits, func(c *models.Commit, _ int) bool {
+			return topic.Includes(c.Hash)
+		})
+		// collapsing a single commit wouldn't make anything tidier
+		if len(topicCommits) < 2 {
+			continue
+		}
+
+		tip := commitsByHash[commit.Parents[1]]
+		// the commits that the topic branch was forked from
+		externalParents := lo.Uniq(lo.Filter(lo.FlatMap(topicCommits, func(c *models.Commit, _ int) []string {
+			return c.Parents
+		}), func(parent string, _ int) bool {
+			return !topic.Includes(parent)
+		}))
+
+		row := *tip
+		row.Parents = externalParents
+		row.Name = fmt.Sprintf("%s (+%d)", tip.Name, len(topicCommits)-1)
+
+		hidden.Add(topic.ToSlice()...)
+		collapsedRows[tip.Hash] = &row
+		hiddenCommits[tip.Hash] = topicCommits
+	}
+
+	result := make([]*models.Commit, 0, len(commits))
+	for _, commit := range commits {
+		if row, ok := collapsedRows[commit.Hash]; ok {
+			result = append(result, row)
+		} else if !hidden.Includes(commit.Hash) {
+			result = append(result, commit)
+		}
+	}
+
+	return result, hiddenCommits
+}
+
+// returns the hashes of the given commits and their ancestors, not following
+// commits that we don't have or that stop returns true for
+func reachableCommits(commitsByHash map[string]*models.Commit, hashes []string, stop func(hash string) bool) *set.Set[string] {
+	reachable := set.New[string]()
+	// cloning because appending to a subslice of a commit's parents would
+	// overwrite its other parents
+	queue := slices.Clone(hashes)
+	for len(queue) > 0 {
+		hash := queue[0]
+		queue = queue[1:]
+
+		commit, ok := commitsByHash[hash]
+		if !ok || reachable.Includes(hash) || (stop != nil && stop(hash)) {
+			continue
+		}
+
+		reachable.Add(hash)
+		queue = append(queue, commit.Parents...)
+	}
+	return reachable
+}
@@ -0,0 +1,64 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DiffRenderedGraphs compares two rendered graphs (as returned by e.g.
+// RenderCommitGraph) row by row, ignoring styling, and returns a
+// human-readable description of the rows that differ. For each such row it
+// shows the old and the new version, followed by a line marking the changed
+// cells. It returns an empty string if the graphs are the same.
+func DiffRenderedGraphs(a, b []string) string {
+	a = StripStyles(a)
+	b = StripStyles(b)
+
+	writer := &strings.Builder{}
+	for i := 0; i < max(len(a), len(b)); i++ {
+		oldCells, oldOk := graphCellsAt(a, i)
+		newCells, newOk := graphCellsAt(b, i)
+		if oldOk && newOk && strings.Join(oldCells, "") == strings.Join(newCells, "") {
+			continue
+		}
+
+		fmt.Fprintf(writer, "row %d:\n", i)
+		if oldOk {
+			fmt.Fprintf(writer, "- %s\n", strings.Join(oldCells, ""))
+		}
+		if newOk {
+			fmt.Fprintf(writer, "+ %s\n", strings.Join(newCells, ""))
+		}
+		if oldOk && newOk {
+			fmt.Fprintf(writer, "  %s\n", strings.TrimRight(changedCellMarkers(oldCells, newCells), " "))
+		}
+	}
+
+	return writer.String()
+}
+
+// splits the row into its cells, each of which is two characters wide
+func graphCellsAt(lines []string, index int) ([]string, bool) {
+	if index >= len(lines) {
+		return nil, false
+	}
+
+	runes := []rune(lines[index])
+	cells := make([]string, 0, (len(runes)+1)/2)
+	for i := 0; i < len(runes); i += 2 {
+		cells = append(cells, string(runes[i:min(i+2, len(runes))]))
+	}
+	return cells, true
+}
+
+func changedCellMarkers(oldCells []string, newCells []string) string {
+	markers := &strings.Builder{}
+	for i := 0; i < max(len(oldCells), len(newCells)); i++ {
+		if i < len(oldCells) && i < len(newCells) && oldCells[i] == newCells[i] {
+			markers.WriteString("  ")
+		} else {
+			markers.WriteString("^^")
+		}
+	}
+	return markers.String()
+}
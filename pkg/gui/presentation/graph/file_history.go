@@ -0,0 +1,71 @@
+package graph
+
+import (
+	"github.com/jesseduffield/generics/set"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/samber/lo"
+)
+
+// FileHistory is meant to be run on the commits before passing them to
+// GetPipeSets, to render the history of a single file. It keeps only the
+// commits for which touchesFile returns true, and connects each of them to
+// its nearest ancestors that touch the file too.
+//
+// It returns the kept commits, along with the parents that are only reached by
+// skipping over commits that don't touch the file, keyed by the hash of the
+// child. Pass those as Options.SkippingParents to draw the pipes to them
+// dashed.
+func FileHistory(commits []*models.Commit, touchesFile func(hash string) bool) ([]*models.Commit, map[string]*set.Set[string]) {
+	commitsByHash := lo.SliceToMap(commits, func(commit *models.Commit) (string, *models.Commit) {
+		return commit.Hash, commit
+	})
+
+	result := make([]*models.Commit, 0, len(commits))
+	skippingParents := map[string]*set.Set[string]{}
+	for _, commit := range commits {
+		if !touchesFile(commit.Hash) {
+			continue
+		}
+
+		parents := []string{}
+		// only created once a parent is skipped
+		var skipping *set.Set[string]
+		visited := set.New[string]()
+		var visit func(hash string, skipped bool)
+		visit = func(hash string, skipped bool) {
+			if visited.Includes(hash) {
+				return
+			}
+			visited.Add(hash)
+
+			parent, ok := commitsByHash[hash]
+			// a parent that hasn't been loaded is kept as is, just like it
+			// would be in the full history
+			if !ok || touchesFile(hash) {
+				parents = append(parents, hash)
+				if skipped {
+					if skipping == nil {
+						skipping = set.New[string]()
+					}
+					skipping.Add(hash)
+				}
+				return
+			}
+			for _, grandparent := range parent.Parents {
+				visit(grandparent, true)
+			}
+		}
+		for _, parent := range commit.Parents {
+			visit(parent, false)
+		}
+
+		rewritten := *commit
+		rewritten.Parents = parents
+		result = append(result, &rewritten)
+		if skipping != nil {
+			skippingParents[commit.Hash] = skipping
+		}
+	}
+
+	return result, skippingParents
+}
@@ -2,10 +2,12 @@ package graph
 
 import (
 	"cmp"
+	"fmt"
 	"runtime"
 	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/jesseduffield/generics/set"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
@@ -29,13 +31,40 @@ type Pipe struct {
 	toHash   string
 	kind     PipeKind
 	style    style.TextStyle
+	// the pipe leads out of a root commit, to Options.RootParentHash. There's
+	// no row for that, so the pipe ends with the root's dot.
+	fromRoot bool
+}
+
+// NewPipe returns a pipe going from the column fromPos of the commit with
+// hash fromHash to the column toPos of its parent with hash toHash, e.g. for
+// feeding hand-made pipe sets to RenderPipeSet in tests.
+func NewPipe(fromPos, toPos int, fromHash, toHash string, kind PipeKind, style style.TextStyle) *Pipe {
+	return &Pipe{
+		fromPos:  fromPos,
+		toPos:    toPos,
+		fromHash: fromHash,
+		toHash:   toHash,
+		kind:     kind,
+		style:    style,
+	}
 }
 
 var highlightStyle = style.FgLightWhite.SetBold()
 
+// InvisibleStyle can be returned from the getStyle callback to hide a commit
+// and the pipes starting from it, while keeping the rest of the graph aligned
+// (e.g. for commits that have been filtered out). It's the zero value of
+// style.TextStyle, which is never a usable style otherwise.
+var InvisibleStyle = style.TextStyle{}
+
+func isInvisible(textStyle style.TextStyle) bool {
+	return textStyle.Style == nil
+}
+
 func ContainsCommitHash(pipes []*Pipe, hash string) bool {
 	for _, pipe := range pipes {
-		if equalHashes(pipe.fromHash, hash) {
+		if utils.EqualHashes(pipe.fromHash, hash) {
 			return true
 		}
 	}
@@ -50,55 +79,359 @@ func (self Pipe) right() int {
 	return max(self.fromPos, self.toPos)
 }
 
+// FromHash returns the hash of the commit the pipe comes from, i.e. the child.
+func (self Pipe) FromHash() string {
+	return self.fromHash
+}
+
+// ToHash returns the hash of the commit the pipe leads to, i.e. the parent.
+func (self Pipe) ToHash() string {
+	return self.toHash
+}
+
 func RenderCommitGraph(commits []*models.Commit, selectedCommitHash string, getStyle func(c *models.Commit) style.TextStyle) []string {
-	pipeSets := GetPipeSets(commits, getStyle)
+	return RenderCommitGraphWithOptions(commits, selectedCommitHash, getStyle, Options{})
+}
+
+// RenderCommitGraphBySelectedIndex is like RenderCommitGraph, but takes the
+// index of the selected commit rather than its hash. An index that is negative
+// or out of range means that no commit is selected.
+func RenderCommitGraphBySelectedIndex(commits []*models.Commit, selectedIndex int, getStyle func(c *models.Commit) style.TextStyle) []string {
+	selectedCommitHash := ""
+	if selectedIndex >= 0 && selectedIndex < len(commits) {
+		selectedCommitHash = commits[selectedIndex].Hash
+	}
+	return RenderCommitGraph(commits, selectedCommitHash, getStyle)
+}
+
+// RenderCommitGraphWithStyles is like RenderCommitGraph, but takes the style of
+// each commit's pipes from the given map, keyed by commit hash. Commits that
+// aren't in the map get the default style.
+func RenderCommitGraphWithStyles(commits []*models.Commit, selectedCommitHash string, styles map[string]style.TextStyle) []string {
+	return RenderCommitGraph(commits, selectedCommitHash, func(c *models.Commit) style.TextStyle {
+		if textStyle, ok := styles[c.Hash]; ok {
+			return textStyle
+		}
+		return style.FgDefault
+	})
+}
+
+func RenderCommitGraphWithOptions(commits []*models.Commit, selectedCommitHash string, getStyle func(c *models.Commit) style.TextStyle, opts Options) []string {
+	if opts.RouteSelectedLineage && selectedCommitHash != "" {
+		opts.HeadHash = selectedCommitHash
+		opts.highlightHeadLineage = true
+	}
+
+	pipeSets := GetPipeSetsWithOptions(commits, getStyle, opts)
 	if len(pipeSets) == 0 {
 		return nil
 	}
 
-	lines := RenderAux(pipeSets, commits, selectedCommitHash)
+	if opts.Braille && !opts.NonInteractive {
+		return renderBraille(pipeSets, commits, selectedCommitHash, &opts)
+	}
+
+	lines := RenderAuxWithOptions(pipeSets, commits, selectedCommitHash, opts)
 
 	return lines
 }
 
+// RenderCommitGraphViewport renders only the rows [offset, offset+height) of
+// the graph, e.g. for a scrollable pane. The pipes are still laid out from the
+// first commit onward, so that the top row shows the pipes coming in from the
+// rows above it rather than starting afresh. Rows beyond the viewport aren't
+// laid out at all. If opts.WIPParentHash is set, the WIP row counts as the
+// first row. Options.Braille is ignored.
+func RenderCommitGraphViewport(commits []*models.Commit, offset int, height int, selectedCommitHash string, getStyle func(c *models.Commit) style.TextStyle, opts Options) []string {
+	offset = max(offset, 0)
+	end := offset + max(height, 0)
+	if opts.DepthLimit <= 0 || opts.DepthLimit > end {
+		opts.DepthLimit = end
+	}
+
+	pipeSets := GetPipeSetsWithOptions(commits, getStyle, opts)
+	end = min(end, len(pipeSets))
+	if offset >= end {
+		return nil
+	}
+
+	return renderRows(pipeSets, opts.withWIPCommit(commits), offset, end, selectedCommitHash, &opts)
+}
+
+// RowRender is a rendered row of the graph along with what it depicts, so that
+// callers don't need to parse the rendered string.
+type RowRender struct {
+	Line string
+	// the hash of the commit of the row
+	Hash    string
+	IsMerge bool
+	// the column of the commit's dot, or -1 if the row is blank (e.g. beyond
+	// Options.DepthLimit)
+	Column int
+	// the hashes of the commits that the row's pipes come from or go to,
+	// including the row's own commit
+	PipeHashes []string
+}
+
+// RenderCommitGraphDetailed is like RenderCommitGraphWithOptions, but returns
+// a RowRender per row. Options.Braille is ignored, as braille lines don't
+// correspond to commits one to one.
+func RenderCommitGraphDetailed(commits []*models.Commit, selectedCommitHash string, getStyle func(c *models.Commit) style.TextStyle, opts Options) []RowRender {
+	pipeSets := GetPipeSetsWithOptions(commits, getStyle, opts)
+	if len(pipeSets) == 0 {
+		return nil
+	}
+
+	lines := RenderAuxWithOptions(pipeSets, commits, selectedCommitHash, opts)
+	commits = opts.withWIPCommit(commits)
+
+	return lo.Map(pipeSets, func(pipes []*Pipe, i int) RowRender {
+		commitPos, _, startCount := summarisePipeSet(pipes)
+		if len(pipes) == 0 {
+			commitPos = -1
+		}
+
+		pipeHashes := lo.Uniq(lo.FlatMap(pipes, func(pipe *Pipe, _ int) []string {
+			return []string{pipe.fromHash, pipe.toHash}
+		}))
+		pipeHashes = lo.Filter(pipeHashes, func(hash string, _ int) bool {
+			// leaving out the placeholders that don't stand for actual commits
+			return hash != "" && hash != opts.startHash() && hash != opts.rootParentHash()
+		})
+
+		return RowRender{
+			Line:       lines[i],
+			Hash:       commits[i].Hash,
+			IsMerge:    startCount > 1,
+			Column:     commitPos,
+			PipeHashes: pipeHashes,
+		}
+	})
+}
+
 func GetPipeSets(commits []*models.Commit, getStyle func(c *models.Commit) style.TextStyle) [][]*Pipe {
-	if len(commits) == 0 {
+	return GetPipeSetsWithOptions(commits, getStyle, Options{})
+}
+
+// The commits must be in topological order (see ValidateTopologicalOrder).
+// If opts.WIPParentHash is set, the first of the returned pipe sets is the one
+// of the virtual WIP commit, so there's one more pipe set than there are commits.
+func GetPipeSetsWithOptions(commits []*models.Commit, getStyle func(c *models.Commit) style.TextStyle, opts Options) [][]*Pipe {
+	pipeSets := make([][]*Pipe, 0, len(commits)+1)
+	EachPipeSetWithOptions(commits, getStyle, opts, func(_ int, pipes []*Pipe) bool {
+		pipeSets = append(pipeSets, pipes)
+		return true
+	})
+	if len(pipeSets) == 0 {
 		return nil
 	}
+	return pipeSets
+}
+
+func EachPipeSet(commits []*models.Commit, getStyle func(c *models.Commit) style.TextStyle, yield func(row int, pipes []*Pipe) bool) {
+	EachPipeSetWithOptions(commits, getStyle, Options{}, yield)
+}
+
+// EachPipeSetWithOptions is like GetPipeSetsWithOptions, but rather than
+// returning all the pipe sets at once, it passes them to yield one row at a
+// time, as they're computed. Only the pipe set of the previous row is kept
+// around, so callers that process the rows as they go (e.g. for a huge
+// history) don't need to hold on to all of them. It stops early when yield
+// returns false.
+func EachPipeSetWithOptions(commits []*models.Commit, getStyle func(c *models.Commit) style.TextStyle, opts Options, yield func(row int, pipes []*Pipe) bool) {
+	commits = opts.withWIPCommit(commits)
+	if len(commits) == 0 {
+		return
+	}
+
+	opts.computeMergeBaseLineage(commits)
+	opts.computeHeadLineage(commits)
+
+	if opts.AuthorColoredDots {
+		commitStyle := getStyle
+		getStyle = func(c *models.Commit) style.TextStyle {
+			if isInvisible(commitStyle(c)) {
+				return InvisibleStyle
+			}
+			return neutralPipeStyle
+		}
+	}
+
+	startPos := 0
+	if opts.reservesFirstColumn() && !opts.isOnHeadLineage(commits[0].Hash) {
+		startPos = 1
+	}
+
+	pipes := []*Pipe{{fromPos: startPos, toPos: startPos, fromHash: opts.startHash(), toHash: commits[0].Hash, kind: STARTS, style: style.FgDefault}}
+	if opts.OmitStartPipe {
+		pipes = []*Pipe{}
+	} else if opts.ContinueFromAbove {
+		// there's no hash for whatever is above, so we leave fromHash empty,
+		// which means it will never be treated as selected
+		pipes = []*Pipe{{fromPos: startPos, toPos: startPos, fromHash: "", toHash: commits[0].Hash, kind: CONTINUES, style: style.FgDefault}}
+	}
 
-	pipes := []*Pipe{{fromPos: 0, toPos: 0, fromHash: "START", toHash: commits[0].Hash, kind: STARTS, style: style.FgDefault}}
+	widthExceeded := false
+	for i, commit := range commits {
+		if opts.isBeyondDepthLimit(i) {
+			if !yield(i, []*Pipe{}) {
+				return
+			}
+			continue
+		}
+
+		pipes = getNextPipes(pipes, commit, getStyle, &opts)
+		// the next row is laid out from the uncapped pipes, so that lanes
+		// keep their place while they're spilled over
+		cappedPipes := capLanes(pipes, opts.MaxLanes)
+		if opts.OnWidthExceeded != nil && !widthExceeded {
+			if width := pipeSetWidth(cappedPipes); width > opts.WidthThreshold {
+				widthExceeded = true
+				opts.OnWidthExceeded(width)
+			}
+		}
+		if !yield(i, cappedPipes) {
+			return
+		}
+	}
+}
 
-	return lo.Map(commits, func(commit *models.Commit, _ int) []*Pipe {
-		pipes = getNextPipes(pipes, commit, getStyle)
+// moves the pipes beyond the first maxLanes columns into the last of them,
+// the spillover lane
+func capLanes(pipes []*Pipe, maxLanes int) []*Pipe {
+	if maxLanes <= 0 || lo.EveryBy(pipes, func(pipe *Pipe) bool { return pipe.right() < maxLanes }) {
 		return pipes
+	}
+
+	return lo.Map(pipes, func(pipe *Pipe, _ int) *Pipe {
+		capped := *pipe
+		capped.fromPos = min(capped.fromPos, maxLanes-1)
+		capped.toPos = min(capped.toPos, maxLanes-1)
+		return &capped
 	})
 }
 
+// the number of lanes beyond the first one that have been spilled over into
+// the last column (see Options.MaxLanes). Each lane has exactly one pipe
+// continuing down on it, so that's what we count.
+func spilledLaneCount(pipes []*Pipe, maxLanes int) int {
+	if maxLanes <= 0 {
+		return 0
+	}
+	return max(lo.CountBy(pipes, func(pipe *Pipe) bool {
+		return pipe.kind != TERMINATES && !pipe.fromRoot && pipe.toPos == maxLanes-1
+	})-1, 0)
+}
+
+// the number of columns taken up by the pipe set
+func pipeSetWidth(pipes []*Pipe) int {
+	return lo.Max(lo.Map(pipes, func(pipe *Pipe, _ int) int { return pipe.right() })) + 1
+}
+
 func RenderAux(pipeSets [][]*Pipe, commits []*models.Commit, selectedCommitHash string) []string {
+	return RenderAuxWithOptions(pipeSets, commits, selectedCommitHash, Options{})
+}
+
+// 0 means no limit beyond GOMAXPROCS
+var maxRenderConcurrency atomic.Int64
+
+// SetMaxRenderConcurrency caps the number of goroutines used to render the
+// graph, independently of GOMAXPROCS (e.g. for reproducible profiling). Pass 0
+// to go back to using GOMAXPROCS.
+func SetMaxRenderConcurrency(n int) {
+	maxRenderConcurrency.Store(int64(max(n, 0)))
+}
+
+const defaultMinRowsPerRenderGoroutine = 500
+
+// 0 means defaultMinRowsPerRenderGoroutine
+var minRowsPerRenderGoroutine atomic.Int64
+
+// SetMinRowsPerRenderGoroutine sets how many rows each goroutine rendering the
+// graph should get at least. Below that, spinning up a goroutine costs more
+// than it saves, so small histories are rendered on a single one. Pass 0 to go
+// back to the default.
+func SetMinRowsPerRenderGoroutine(n int) {
+	minRowsPerRenderGoroutine.Store(int64(max(n, 0)))
+}
+
+// the number of goroutines to render the given number of rows with
+func renderConcurrency(rowCount int) int {
 	maxProcs := runtime.GOMAXPROCS(0)
+	if limit := int(maxRenderConcurrency.Load()); limit > 0 {
+		maxProcs = min(maxProcs, limit)
+	}
+
+	minRowsPerProc := int(minRowsPerRenderGoroutine.Load())
+	if minRowsPerProc == 0 {
+		minRowsPerProc = defaultMinRowsPerRenderGoroutine
+	}
+
+	return max(min(maxProcs, rowCount/minRowsPerProc), 1)
+}
+
+func RenderAuxWithOptions(pipeSets [][]*Pipe, commits []*models.Commit, selectedCommitHash string, opts Options) []string {
+	return renderRows(pipeSets, opts.withWIPCommit(commits), 0, len(pipeSets), selectedCommitHash, &opts)
+}
+
+// RenderGraphColumns is like RenderAuxWithOptions, but pads the rows to the
+// width of the widest one, so that the graph can be laid out as a column of
+// its own, e.g. beside a separate column of commit subjects.
+func RenderGraphColumns(pipeSets [][]*Pipe, commits []*models.Commit, selectedCommitHash string, opts Options) []string {
+	lines := RenderAuxWithOptions(pipeSets, commits, selectedCommitHash, opts)
+	width := lo.Max(lo.Map(lines, func(line string, _ int) int {
+		return utils.StringWidth(utils.Decolorise(line))
+	}))
+
+	return lo.Map(lines, func(line string, _ int) string {
+		return utils.WithPadding(line, width, utils.AlignLeft)
+	})
+}
+
+// renders the rows [from, to) of the given pipe sets. The commits are expected
+// to already include the WIP commit, if any.
+func renderRows(pipeSets [][]*Pipe, commits []*models.Commit, from int, to int, selectedCommitHash string, opts *Options) []string {
+	opts.computePatchEquivalentStyles(commits)
+	opts.computeAuthorStyles(commits[from:to])
+	opts.computeCoAuthorsBadges(commits[from:to])
+	maxProcs := renderConcurrency(to - from)
+
+	width := 0
+	if opts.RightAlign || opts.GuideInterval > 0 {
+		for _, pipeSet := range pipeSets {
+			for _, pipe := range pipeSet {
+				width = max(width, pipe.right()+1)
+			}
+		}
+	}
 
 	// splitting up the rendering of the graph into multiple goroutines allows us to render the graph in parallel
 	chunks := make([][]string, maxProcs)
-	perProc := len(pipeSets) / maxProcs
+	perProc := (to - from) / maxProcs
 
 	wg := sync.WaitGroup{}
 	wg.Add(maxProcs)
 
 	for i := 0; i < maxProcs; i++ {
 		go func() {
-			from := i * perProc
-			to := (i + 1) * perProc
+			chunkFrom := from + i*perProc
+			chunkTo := from + (i+1)*perProc
 			if i == maxProcs-1 {
-				to = len(pipeSets)
+				chunkTo = to
 			}
-			innerLines := make([]string, 0, to-from)
-			for j, pipeSet := range pipeSets[from:to] {
-				k := from + j
-				var prevCommit *models.Commit
+			innerLines := make([]string, 0, chunkTo-chunkFrom)
+			for j, pipeSet := range pipeSets[chunkFrom:chunkTo] {
+				k := chunkFrom + j
+				row := rowContext{
+					commit: commits[k],
+					index:  k,
+					isLast: k == len(pipeSets)-1,
+					width:  width,
+				}
 				if k > 0 {
-					prevCommit = commits[k-1]
+					row.prevCommit = commits[k-1]
 				}
-				line := renderPipeSet(pipeSet, selectedCommitHash, prevCommit)
+				line := renderPipeSetWithOptions(pipeSet, selectedCommitHash, row, opts)
 				innerLines = append(innerLines, line)
 			}
 			chunks[i] = innerLines
@@ -111,7 +444,9 @@ func RenderAux(pipeSets [][]*Pipe, commits []*models.Commit, selectedCommitHash
 	return lo.Flatten(chunks)
 }
 
-func getNextPipes(prevPipes []*Pipe, commit *models.Commit, getStyle func(c *models.Commit) style.TextStyle) []*Pipe {
+func getNextPipes(prevPipes []*Pipe, commit *models.Commit, getStyle func(c *models.Commit) style.TextStyle, opts *Options) []*Pipe {
+	parents := opts.parentsOf(commit)
+
 	maxPos := 0
 	for _, pipe := range prevPipes {
 		if pipe.toPos > maxPos {
@@ -120,57 +455,73 @@ func getNextPipes(prevPipes []*Pipe, commit *models.Commit, getStyle func(c *mod
 	}
 
 	// a pipe that terminated in the previous line has no bearing on the current line
-	// so we'll filter those out
+	// so we'll filter those out. Same goes for a pipe starting from a root commit:
+	// there's no row for its parent so the pipe ends with the root's dot.
 	currentPipes := lo.Filter(prevPipes, func(pipe *Pipe, _ int) bool {
-		return pipe.kind != TERMINATES
+		return pipe.kind != TERMINATES && !pipe.fromRoot
 	})
 
-	newPipes := make([]*Pipe, 0, len(currentPipes)+len(commit.Parents))
+	// every current pipe either terminates or continues, and on top of that we
+	// start one pipe per parent (or a single one for a root commit)
+	newPipes := make([]*Pipe, 0, len(currentPipes)+max(len(parents), 1))
 	// start by assuming that we've got a brand new commit not related to any preceding commit.
 	// (this only happens when we're doing `git log --all`). These will be tacked onto the far end.
 	pos := maxPos + 1
+	if len(prevPipes) == 0 {
+		// the first commit, without a start pipe leading into it
+		pos = opts.firstColumnFor(commit.Hash, commit.Hash)
+	}
 	for _, pipe := range currentPipes {
-		if equalHashes(pipe.toHash, commit.Hash) {
+		if utils.EqualHashes(pipe.toHash, commit.Hash) {
 			// turns out this commit does have a descendant so we'll place it right under the first instance
 			pos = pipe.toPos
 			break
 		}
 	}
+	if opts.isOnHeadLineage(commit.Hash) {
+		pos = 0
+	}
 
 	// a taken spot is one where a current pipe is ending on
 	takenSpots := set.New[int]()
 	// a traversed spot is one where a current pipe is starting on, ending on, or passing through
 	traversedSpots := set.New[int]()
+	// the pipe to the first parent ends on the commit's own spot. This usually
+	// counts as taken anyway because of the pipes terminating there, but not
+	// when the commit is a new tip, in which case a merge's other parents would
+	// otherwise be placed on the same spot.
+	takenSpots.Add(pos)
 
-	if len(commit.Parents) > 0 { // merge commit
+	if len(parents) > 0 { // merge commit
 		newPipes = append(newPipes, &Pipe{
 			fromPos:  pos,
 			toPos:    pos,
 			fromHash: commit.Hash,
-			toHash:   commit.Parents[0],
+			toHash:   parents[0],
 			kind:     STARTS,
-			style:    getStyle(commit),
+			style:    opts.pipeStyle(commit, parents[0], getStyle),
 		})
-	} else if len(commit.Parents) == 0 { // root commit
+	} else if len(parents) == 0 { // root commit
 		newPipes = append(newPipes, &Pipe{
 			fromPos:  pos,
 			toPos:    pos,
 			fromHash: commit.Hash,
-			toHash:   models.EmptyTreeCommitHash,
+			toHash:   opts.rootParentHash(),
 			kind:     STARTS,
-			style:    getStyle(commit),
+			style:    opts.pipeStyle(commit, opts.rootParentHash(), getStyle),
+			fromRoot: true,
 		})
 	}
 
 	traversedSpotsForContinuingPipes := set.New[int]()
 	for _, pipe := range currentPipes {
-		if !equalHashes(pipe.toHash, commit.Hash) {
+		if !utils.EqualHashes(pipe.toHash, commit.Hash) {
 			traversedSpotsForContinuingPipes.Add(pipe.toPos)
 		}
 	}
 
-	getNextAvailablePosForContinuingPipe := func() int {
-		i := 0
+	getNextAvailablePosForContinuingPipe := func(pipe *Pipe) int {
+		i := opts.firstColumnFor(pipe.fromHash, pipe.toHash)
 		for {
 			if !traversedSpots.Includes(i) {
 				return i
@@ -179,8 +530,8 @@ func getNextPipes(prevPipes []*Pipe, commit *models.Commit, getStyle func(c *mod
 		}
 	}
 
-	getNextAvailablePosForNewPipe := func() int {
-		i := 0
+	getNextAvailablePosForNewPipe := func(fromHash string, toHash string) int {
+		i := opts.firstColumnFor(fromHash, toHash)
 		for {
 			// a newly created pipe is not allowed to end on a spot that's already taken,
 			// nor on a spot that's been traversed by a continuing pipe.
@@ -203,7 +554,7 @@ func getNextPipes(prevPipes []*Pipe, commit *models.Commit, getStyle func(c *mod
 	}
 
 	for _, pipe := range currentPipes {
-		if equalHashes(pipe.toHash, commit.Hash) {
+		if utils.EqualHashes(pipe.toHash, commit.Hash) {
 			// terminating here
 			newPipes = append(newPipes, &Pipe{
 				fromPos:  pipe.toPos,
@@ -216,7 +567,10 @@ func getNextPipes(prevPipes []*Pipe, commit *models.Commit, getStyle func(c *mod
 			traverse(pipe.toPos, pos)
 		} else if pipe.toPos < pos {
 			// continuing here
-			availablePos := getNextAvailablePosForContinuingPipe()
+			availablePos := pipe.toPos
+			if !opts.StableLanes {
+				availablePos = getNextAvailablePosForContinuingPipe(pipe)
+			}
 			newPipes = append(newPipes, &Pipe{
 				fromPos:  pipe.toPos,
 				toPos:    availablePos,
@@ -229,9 +583,9 @@ func getNextPipes(prevPipes []*Pipe, commit *models.Commit, getStyle func(c *mod
 		}
 	}
 
-	if commit.IsMerge() {
-		for _, parent := range commit.Parents[1:] {
-			availablePos := getNextAvailablePosForNewPipe()
+	if len(parents) > 1 {
+		for _, parent := range parents[1:] {
+			availablePos := getNextAvailablePosForNewPipe(commit.Hash, parent)
 			// need to act as if continuing pipes are going to continue on the same line.
 			newPipes = append(newPipes, &Pipe{
 				fromPos:  pos,
@@ -239,7 +593,7 @@ func getNextPipes(prevPipes []*Pipe, commit *models.Commit, getStyle func(c *mod
 				fromHash: commit.Hash,
 				toHash:   parent,
 				kind:     STARTS,
-				style:    getStyle(commit),
+				style:    opts.pipeStyle(commit, parent, getStyle),
 			})
 
 			takenSpots.Add(availablePos)
@@ -247,10 +601,10 @@ func getNextPipes(prevPipes []*Pipe, commit *models.Commit, getStyle func(c *mod
 	}
 
 	for _, pipe := range currentPipes {
-		if !equalHashes(pipe.toHash, commit.Hash) && pipe.toPos > pos {
+		if !utils.EqualHashes(pipe.toHash, commit.Hash) && pipe.toPos > pos {
 			// continuing on, potentially moving left to fill in a blank spot
 			last := pipe.toPos
-			for i := pipe.toPos; i > pos; i-- {
+			for i := pipe.toPos; i > pos && !opts.StableLanes; i-- {
 				if takenSpots.Includes(i) || traversedSpots.Includes(i) {
 					break
 				} else {
@@ -269,22 +623,99 @@ func getNextPipes(prevPipes []*Pipe, commit *models.Commit, getStyle func(c *mod
 		}
 	}
 
+	if opts.isHidden(commit) {
+		newPipes = passThrough(newPipes, pos)
+	}
+
 	// not efficient but doing it for now: sorting my pipes by toPos, then by kind
+	// SortFunc isn't stable, so we need a total order for the result (and hence
+	// the way crossing pipes are drawn) to be deterministic
 	slices.SortFunc(newPipes, func(a, b *Pipe) int {
-		if a.toPos == b.toPos {
-			return cmp.Compare(a.kind, b.kind)
-		}
-		return cmp.Compare(a.toPos, b.toPos)
+		return cmp.Or(
+			cmp.Compare(a.toPos, b.toPos),
+			cmp.Compare(a.kind, b.kind),
+			cmp.Compare(a.fromPos, b.fromPos),
+			cmp.Compare(a.fromHash, b.fromHash),
+		)
 	})
 
 	return newPipes
 }
 
-func renderPipeSet(
+// Joins the pipe coming straight down into a hidden commit with the pipe
+// starting from it to its first parent, into a single pipe continuing past it.
+// By keeping the fromHash of the pipe coming in, a run of hidden commits is
+// traversed by one pipe from the last visible commit above. Any other pipes
+// coming into the commit still terminate there.
+func passThrough(pipes []*Pipe, pos int) []*Pipe {
+	isStraight := func(pipe *Pipe) bool { return pipe.fromPos == pos && pipe.toPos == pos }
+	incoming, incomingIdx, found := lo.FindIndexOf(pipes, func(pipe *Pipe) bool {
+		return pipe.kind == TERMINATES && isStraight(pipe)
+	})
+	if !found {
+		return pipes
+	}
+	outgoing, outgoingIdx, found := lo.FindIndexOf(pipes, func(pipe *Pipe) bool {
+		return pipe.kind == STARTS && isStraight(pipe)
+	})
+	if !found {
+		return pipes
+	}
+
+	pipes[incomingIdx] = &Pipe{
+		fromPos:  pos,
+		toPos:    pos,
+		fromHash: incoming.fromHash,
+		toHash:   outgoing.toHash,
+		kind:     CONTINUES,
+		style:    incoming.style,
+		fromRoot: outgoing.fromRoot,
+	}
+	return slices.Delete(pipes, outgoingIdx, outgoingIdx+1)
+}
+
+// RenderPipeSet renders a single row of the graph from its pipes (e.g. as
+// returned by GetPipeSets, or built with NewPipe), with the default options.
+// prevCommit is the commit of the row above, if any; it's needed to decide
+// whether to highlight the row when the selected commit is right above it.
+func RenderPipeSet(
 	pipes []*Pipe,
 	selectedCommitHash string,
 	prevCommit *models.Commit,
 ) string {
+	return renderPipeSetWithOptions(pipes, selectedCommitHash, rowContext{prevCommit: prevCommit}, &Options{})
+}
+
+// what we know about the row being rendered, beyond its pipes
+type rowContext struct {
+	// the commit whose row we're rendering. May be nil if the caller only has
+	// the pipes to go on.
+	commit     *models.Commit
+	prevCommit *models.Commit
+	// index of the row among the rows being rendered
+	index int
+	// whether this is the last of the rows being rendered
+	isLast bool
+	// the number of columns of the widest row being rendered. Only needed when
+	// right-aligning the graph or drawing guides.
+	width int
+}
+
+func renderPipeSetWithOptions(
+	pipes []*Pipe,
+	selectedCommitHash string,
+	row rowContext,
+	opts *Options,
+) string {
+	// using a string builder here for the sake of performance
+	writer := &strings.Builder{}
+	renderPipeSetTo(writer, pipes, selectedCommitHash, row, opts)
+	return writer.String()
+}
+
+// returns the column of the commit's dot, the rightmost column, and the number
+// of pipes starting from the commit
+func summarisePipeSet(pipes []*Pipe) (int, int, int) {
 	maxPos := 0
 	commitPos := 0
 	startCount := 0
@@ -300,6 +731,21 @@ func renderPipeSet(
 			maxPos = pipe.right()
 		}
 	}
+	return commitPos, maxPos, startCount
+}
+
+// renderPipeSetTo is like renderPipeSetWithOptions, but appends the row to the
+// given builder rather than returning it, so that a caller rendering many rows
+// can reuse a single buffer.
+func renderPipeSetTo(
+	writer *strings.Builder,
+	pipes []*Pipe,
+	selectedCommitHash string,
+	row rowContext,
+	opts *Options,
+) {
+	commit := row.commit
+	commitPos, maxPos, startCount := summarisePipeSet(pipes)
 	isMerge := startCount > 1
 
 	cells := lo.Map(lo.Range(maxPos+1), func(i int, _ int) *Cell {
@@ -318,7 +764,7 @@ func renderPipeSet(
 			cells[right].setLeft(style)
 		}
 
-		if pipe.kind == STARTS || pipe.kind == CONTINUES {
+		if (pipe.kind == STARTS || pipe.kind == CONTINUES) && !pipe.fromRoot {
 			cells[pipe.toPos].setDown(style)
 		}
 		if pipe.kind == TERMINATES || pipe.kind == CONTINUES {
@@ -326,27 +772,32 @@ func renderPipeSet(
 		}
 	}
 
-	// we don't want to highlight two commits if they're contiguous. We only want
-	// to highlight multiple things if there's an actual visible pipe involved.
-	highlight := true
-	if prevCommit != nil && equalHashes(prevCommit.Hash, selectedCommitHash) {
-		highlight = false
-		for _, pipe := range pipes {
-			if equalHashes(pipe.fromHash, selectedCommitHash) && (pipe.kind != TERMINATES || pipe.fromPos != pipe.toPos) {
-				highlight = true
-			}
-		}
-	}
+	highlight := shouldHighlightRow(pipes, selectedCommitHash, row.prevCommit)
+
+	// invisible pipes still take up their columns so that the visible ones stay
+	// aligned, but we don't draw them
+	visiblePipes := lo.Filter(pipes, func(pipe *Pipe, _ int) bool {
+		return !isInvisible(pipe.style)
+	})
+	// a row without any pipes (e.g. beyond the depth limit) is left blank, and
+	// a hidden commit only has pipes passing through
+	isInvisibleCommit := len(pipes) == 0 || (commit != nil && opts.isHidden(commit)) || startCount > 0 && !lo.SomeBy(visiblePipes, func(pipe *Pipe) bool {
+		return pipe.kind == STARTS
+	})
 
 	// so we have our commit pos again, now it's time to build the cells.
 	// we'll handle the one that's sourced from our selected commit last so that it can override the other cells.
-	selectedPipes, nonSelectedPipes := utils.Partition(pipes, func(pipe *Pipe) bool {
-		return highlight && equalHashes(pipe.fromHash, selectedCommitHash)
+	selectedPipes, nonSelectedPipes := utils.Partition(visiblePipes, func(pipe *Pipe) bool {
+		return highlight && utils.EqualHashes(pipe.fromHash, selectedCommitHash)
 	})
 
 	for _, pipe := range nonSelectedPipes {
 		if pipe.kind == STARTS {
-			renderPipe(pipe, pipe.style, true)
+			renderPipe(pipe, opts.downwardStyle(pipe.style), true)
+			if opts.DownwardStyle != nil && pipe.toPos == commitPos {
+				// the dot itself keeps the commit's style
+				cells[commitPos].setStyle(pipe.style)
+			}
 		}
 	}
 
@@ -368,29 +819,213 @@ func renderPipeSet(
 		}
 	}
 
+	if opts.HasMore && row.isLast {
+		// hint that there are more commits to load, by drawing the pipes that
+		// continue downwards as dashed
+		for _, pipe := range visiblePipes {
+			if pipe.kind != TERMINATES && !pipe.fromRoot && pipe.toPos != commitPos {
+				cells[pipe.toPos].setDashed()
+			}
+		}
+	}
+
+	if opts.OffScreenParents && row.isLast && commit != nil {
+		for _, pipe := range visiblePipes {
+			if pipe.kind == STARTS && pipe.fromHash == commit.Hash && !pipe.fromRoot {
+				cells[pipe.toPos].setOffScreen()
+			}
+		}
+	}
+
+	if opts.MergeArrowheads && isMerge && !isInvisibleCommit {
+		firstParentHash := ""
+		if commit != nil {
+			firstParentHash = opts.parentsOf(commit)[0]
+		}
+		for _, pipe := range visiblePipes {
+			if pipe.kind != STARTS || pipe.toPos == commitPos ||
+				(firstParentHash != "" && pipe.toHash == firstParentHash) {
+				continue
+			}
+			// the arrowhead goes on the pipe right next to the dot
+			if pipe.toPos > commitPos {
+				cells[commitPos].setArrowhead(ArrowheadLeftSymbol)
+			} else {
+				cells[commitPos-1].setArrowhead(ArrowheadRightSymbol)
+			}
+		}
+	}
+
+	if spilled := spilledLaneCount(visiblePipes, opts.MaxLanes); spilled > 0 {
+		cells[opts.MaxLanes-1].setSpillover(spilled)
+	}
+
 	cType := COMMIT
-	if isMerge {
+	if commit != nil && isWIP(commit) {
+		cType = WIP
+	} else if commit != nil && opts.isStash(commit) {
+		cType = STASH
+	} else if commit != nil && opts.isGrafted(commit) {
+		cType = GRAFTED
+	} else if commit != nil && opts.isCollapsed(commit) {
+		cType = COLLAPSED
+	} else if !isMerge && commit != nil && opts.isFolded(commit) {
+		cType = FOLDED
+	} else if isMerge {
 		cType = MERGE
+	} else if commit != nil && opts.isEmpty(commit) {
+		cType = EMPTY
 	}
 
-	cells[commitPos].setType(cType)
+	if !isInvisibleCommit {
+		cells[commitPos].setType(cType)
+		if cType == COMMIT && commit != nil {
+			cells[commitPos].setWeight(opts.dotWeight(commit)).setGlyph(opts.dotGlyph(commit))
+		}
+	}
+	// the selection highlight takes precedence over any special dot style
+	if commit != nil && !isInvisibleCommit && !(highlight && utils.EqualHashes(commit.Hash, selectedCommitHash)) {
+		if dotStyle, ok := opts.dotStyle(commit); ok {
+			cells[commitPos].setStyle(dotStyle)
+		} else if opts.AuthorColoredDots && (cType == COMMIT || cType == MERGE) {
+			cells[commitPos].setStyle(opts.authorStyles[commit.AuthorName])
+		}
+	}
 
-	// using a string builder here for the sake of performance
-	writer := &strings.Builder{}
-	writer.Grow(len(cells) * 2)
-	for _, cell := range cells {
-		cell.render(writer)
+	if opts.GuideInterval > 0 && len(pipes) > 0 {
+		cells = addGuides(cells, row.width, opts.GuideInterval)
 	}
-	return writer.String()
+
+	// the selected row's background (if any) is applied on top of this
+	if opts.StripeBackground != nil && row.index%2 == 1 {
+		for _, cell := range cells {
+			cell.setBackground(*opts.StripeBackground)
+		}
+	}
+
+	if opts.SelectedRowBackground != nil && commit != nil && utils.EqualHashes(commit.Hash, selectedCommitHash) {
+		for _, cell := range cells {
+			cell.setBackground(*opts.SelectedRowBackground)
+		}
+	}
+
+	if opts.BasicColors {
+		for _, cell := range cells {
+			cell.toBasicColors()
+		}
+	}
+
+	if opts.RightAlign {
+		cells = mirrorCells(cells, row.width)
+	}
+
+	if !opts.TimestampGutterNow.IsZero() {
+		var timestamp int64
+		if commit != nil {
+			timestamp = commit.UnixTimestamp
+		}
+		writer.WriteString(utils.UnixToTimeAgoCell(opts.TimestampGutterNow, timestamp))
+	}
+
+	writer.Grow(len(cells) * 2 * (1 + max(opts.ColumnGap, 0)))
+	for i, cell := range cells {
+		cell.render(writer, opts)
+		if i < len(cells)-1 {
+			cell.renderFiller(writer, opts.ColumnGap, opts)
+		}
+	}
+
+	if opts.DebugColumns && !isInvisibleCommit {
+		fmt.Fprintf(writer, "[col=%d] ", commitPos)
+	}
+
+	if commit != nil && !isInvisibleCommit && !opts.RightAlign {
+		renderCoAuthorsBadge(writer, opts.coAuthorsBadges[commit.Hash], opts)
+	}
+}
+
+// pads the cells up to the given width and marks every interval-th column that
+// is otherwise empty as a guide
+func addGuides(cells []*Cell, width int, interval int) []*Cell {
+	for len(cells) < width {
+		cells = append(cells, &Cell{cellType: CONNECTION, style: style.FgDefault})
+	}
+
+	for i := interval; i < len(cells); i += interval {
+		if cells[i].isEmpty() {
+			cells[i].setGuide()
+		}
+	}
+	return cells
+}
+
+func renderCoAuthorsBadge(writer *strings.Builder, badge string, opts *Options) {
+	if badge == "" {
+		return
+	}
+
+	if opts.NonInteractive {
+		badge = utils.Decolorise(badge)
+	}
+	writer.WriteString(badge)
+	writer.WriteString(" ")
 }
 
-func equalHashes(a, b string) bool {
-	// if our selectedCommitHash is an empty string we treat that as meaning there is no selected commit hash
-	if a == "" || b == "" {
-		return false
+// pads the cells up to the given width and then flips them horizontally, so
+// that the first column ends up on the right
+func mirrorCells(cells []*Cell, width int) []*Cell {
+	for len(cells) < width {
+		cells = append(cells, &Cell{cellType: CONNECTION, style: style.FgDefault})
 	}
 
-	length := min(len(a), len(b))
-	// parent hashes are only stored up to 20 characters for some reason so we'll truncate to that for comparison
-	return a[:length] == b[:length]
+	mirrored := make([]*Cell, len(cells))
+	for i := range cells {
+		j := len(cells) - 1 - i
+		cell := *cells[j]
+		cell.left, cell.right = cell.right, cell.left
+		// a cell renders the connection to its right, which after flipping is
+		// the connection that used to be to the left of the original cell
+		cell.rightStyle = nil
+		cell.arrowhead = 0
+		if j > 0 {
+			cell.rightStyle = cells[j-1].rightStyle
+			cell.arrowhead = flippedArrowhead(cells[j-1].arrowhead)
+		}
+		mirrored[i] = &cell
+	}
+	return mirrored
+}
+
+func flippedArrowhead(arrowhead rune) rune {
+	switch arrowhead {
+	case ArrowheadLeftSymbol:
+		return ArrowheadRightSymbol
+	case ArrowheadRightSymbol:
+		return ArrowheadLeftSymbol
+	}
+	return arrowhead
+}
+
+// StripStyles returns the given rendered graph lines without any of the ANSI
+// escape sequences used for styling them.
+func StripStyles(lines []string) []string {
+	return lo.Map(lines, func(line string, _ int) string {
+		return utils.Decolorise(line)
+	})
+}
+
+// we don't want to highlight two commits if they're contiguous. We only want
+// to highlight multiple things if there's an actual visible pipe involved.
+func shouldHighlightRow(pipes []*Pipe, selectedCommitHash string, prevCommit *models.Commit) bool {
+	if prevCommit == nil || !utils.EqualHashes(prevCommit.Hash, selectedCommitHash) {
+		return true
+	}
+
+	for _, pipe := range pipes {
+		if utils.EqualHashes(pipe.fromHash, selectedCommitHash) && (pipe.kind != TERMINATES || pipe.fromPos != pipe.toPos) {
+			return true
+		}
+	}
+
+	return false
 }
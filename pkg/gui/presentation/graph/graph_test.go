@@ -1,16 +1,26 @@
 package graph
 
 import (
+	"flag"
 	"fmt"
 	"math/rand"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
 	"strings"
 	"testing"
+	"time"
+	"unicode/utf8"
 
 	"github.com/gookit/color"
+	"github.com/jesseduffield/generics/set"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/gui/presentation/authors"
 	"github.com/jesseduffield/lazygit/pkg/gui/style"
 	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
+	"github.com/stefanhaller/git-todo-parser/todo"
 	"github.com/stretchr/testify/assert"
 	"github.com/xo/terminfo"
 )
@@ -19,6 +29,7 @@ func TestRenderCommitGraph(t *testing.T) {
 	tests := []struct {
 		name           string
 		commits        []*models.Commit
+		opts           Options
 		expectedOutput string
 	}{
 		{
@@ -211,6 +222,132 @@ func TestRenderCommitGraph(t *testing.T) {
 			C ◯ │ ╭───╯ │
 			D ◯ │ │ ╭───╯`,
 		},
+		{
+			name: "with a single root commit",
+			commits: []*models.Commit{
+				{Hash: "1", Parents: []string{}},
+			},
+			expectedOutput: `
+			1 ◯`,
+		},
+		{
+			name: "with a root commit that's not at the bottom",
+			commits: []*models.Commit{
+				{Hash: "1", Parents: []string{"3"}},
+				{Hash: "R", Parents: []string{}},
+				{Hash: "3", Parents: []string{"5"}},
+				{Hash: "5", Parents: []string{}},
+			},
+			expectedOutput: `
+			1 ◯
+			R │ ◯
+			3 ◯
+			5 ◯`,
+		},
+		{
+			name: "with a grafted commit",
+			commits: []*models.Commit{
+				{Hash: "1", Parents: []string{"2"}},
+				{Hash: "2", Parents: []string{"X"}},
+				{Hash: "3", Parents: []string{"4"}},
+				{Hash: "4", Parents: []string{}},
+			},
+			opts: Options{RewrittenParents: map[string][]string{"2": {"3"}}},
+			expectedOutput: `
+			1 ◯
+			2 ◎
+			3 ◯
+			4 ◯`,
+		},
+		{
+			name: "with a column gap",
+			commits: []*models.Commit{
+				{Hash: "1", Parents: []string{"2", "3"}},
+				{Hash: "3", Parents: []string{"2"}},
+				{Hash: "2", Parents: []string{"4"}},
+			},
+			opts: Options{ColumnGap: 1},
+			expectedOutput: `
+			1 ⏣───╮
+			3 │   ◯
+			2 ◯───╯`,
+		},
+		{
+			name: "with more commits to load",
+			commits: []*models.Commit{
+				{Hash: "1", Parents: []string{"2", "3"}},
+				{Hash: "2", Parents: []string{"4", "5"}},
+			},
+			opts: Options{HasMore: true},
+			expectedOutput: `
+			1 ⏣─╮
+			2 ⏣─╎─╮`,
+		},
+		{
+			name: "with off-screen parents",
+			commits: []*models.Commit{
+				commit("1", parents("2", "3")),
+				commit("3", parents("4")),
+				commit("2", parents("5", "6")),
+			},
+			opts: Options{OffScreenParents: true},
+			expectedOutput: `
+			1 ⏣─╮
+			3 │ ◯
+			2 ⏣─│─↓`,
+		},
+		{
+			name: "with off-screen parents of a plain commit",
+			commits: []*models.Commit{
+				commit("1", parents("2")),
+				commit("2", parents("3")),
+			},
+			opts: Options{OffScreenParents: true},
+			expectedOutput: `
+			1 ◯
+			2 ◯↓`,
+		},
+		{
+			name: "with a root as the last commit",
+			commits: []*models.Commit{
+				commit("1", parents("2")),
+				commit("2"),
+			},
+			opts: Options{OffScreenParents: true},
+			expectedOutput: `
+			1 ◯
+			2 ◯`,
+		},
+		{
+			name: "with an empty commit",
+			commits: []*models.Commit{
+				commit("1", parents("2", "3")),
+				commit("3", parents("2")),
+				commit("2", parents("4")),
+				commit("4"),
+			},
+			opts: Options{EmptyHashes: set.NewFromSlice([]string{"1", "3"})},
+			expectedOutput: `
+			1 ⏣─╮
+			3 │ ◦
+			2 ◯─╯
+			4 ◯`,
+		},
+		{
+			name: "with a stash entry",
+			commits: []*models.Commit{
+				{Hash: "S", Parents: []string{"2", "I", "U"}},
+				{Hash: "1", Parents: []string{"2"}},
+				{Hash: "2", Parents: []string{"3"}},
+				{Hash: "3", Parents: []string{"4"}},
+			},
+			opts: Options{StashHashes: set.NewFromSlice([]string{"S"})},
+			expectedOutput: `
+			S ◈
+			1 │ ◯
+			2 ◯─╯
+			3 ◯`,
+		},
 	}
 
 	oldColorLevel := color.ForceSetColorLevel(terminfo.ColorLevelMillions)
@@ -219,7 +356,7 @@ func TestRenderCommitGraph(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
-			lines := RenderCommitGraph(test.commits, "blah", getStyle)
+			lines := RenderCommitGraphWithOptions(test.commits, "blah", getStyle, test.opts)
 
 			trimmedExpectedOutput := ""
 			for _, line := range strings.Split(strings.TrimPrefix(test.expectedOutput, "\n"), "\n") {
@@ -242,6 +379,86 @@ func TestRenderCommitGraph(t *testing.T) {
 	}
 }
 
+var updateGolden = flag.Bool("update", false, "update the golden files of TestRenderCommitGraphGolden")
+
+// Renders each testdata/<name>.commits fixture and compares the result against
+// testdata/<name>.golden. Run `go test -run TestRenderCommitGraphGolden -update`
+// to regenerate the golden files after an intentional change to the renderer.
+func TestRenderCommitGraphGolden(t *testing.T) {
+	fixtures, err := filepath.Glob("testdata/*.commits")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, fixtures)
+
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+
+	for _, fixture := range fixtures {
+		name := strings.TrimSuffix(filepath.Base(fixture), ".commits")
+		t.Run(name, func(t *testing.T) {
+			content, err := os.ReadFile(fixture)
+			assert.NoError(t, err)
+
+			lines := StripStyles(RenderCommitGraph(parseCommitsFixture(string(content)), "", getStyle))
+			// trailing spaces are trimmed so that editors don't mess with the golden files
+			actual := strings.Join(lo.Map(lines, func(line string, _ int) string {
+				return strings.TrimRight(line, " ")
+			}), "\n") + "\n"
+
+			goldenPath := filepath.Join("testdata", name+".golden")
+			if *updateGolden {
+				assert.NoError(t, os.WriteFile(goldenPath, []byte(actual), 0o644))
+				return
+			}
+
+			expected, err := os.ReadFile(goldenPath)
+			assert.NoError(t, err)
+			assert.Equal(t, string(expected), actual)
+		})
+	}
+}
+
+// for authoring commit fixtures tersely, e.g.
+//
+//	commits := []*models.Commit{
+//		commit("A", parents("B", "C")),
+//		commit("C", parents("B")),
+//		commit("B"),
+//	}
+type commitOption func(c *models.Commit)
+
+func commit(hash string, opts ...commitOption) *models.Commit {
+	c := &models.Commit{Hash: hash}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func parents(hashes ...string) commitOption {
+	return func(c *models.Commit) {
+		c.Parents = hashes
+	}
+}
+
+func timestamp(t time.Time) commitOption {
+	return func(c *models.Commit) {
+		c.UnixTimestamp = t.Unix()
+	}
+}
+
+// each non-empty line is a commit hash followed by the hashes of its parents;
+// lines starting with # are comments
+func parseCommitsFixture(content string) []*models.Commit {
+	commits := []*models.Commit{}
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		commits = append(commits, &models.Commit{Hash: fields[0], Parents: fields[1:]})
+	}
+	return commits
+}
+
 func TestRenderPipeSet(t *testing.T) {
 	cyan := style.FgCyan
 	red := style.FgRed
@@ -454,7 +671,7 @@ func TestRenderPipeSet(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			actualStr := renderPipeSet(test.pipes, "selected", test.prevCommit)
+			actualStr := RenderPipeSet(test.pipes, "selected", test.prevCommit)
 			t.Log("actual cells:")
 			t.Log(actualStr)
 			expectedStr := ""
@@ -473,6 +690,86 @@ func TestRenderPipeSet(t *testing.T) {
 	}
 }
 
+func TestRenderPipeSetTo(t *testing.T) {
+	commits := []*models.Commit{
+		{Hash: "1", Parents: []string{"2", "3"}},
+		{Hash: "3", Parents: []string{"2"}},
+		{Hash: "2", Parents: []string{"4"}},
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+	pipeSets := GetPipeSets(commits, getStyle)
+
+	writer := &strings.Builder{}
+	expected := ""
+	for i, pipeSet := range pipeSets {
+		row := rowContext{commit: commits[i], index: i}
+		renderPipeSetTo(writer, pipeSet, "1", row, &Options{})
+		expected += renderPipeSetWithOptions(pipeSet, "1", row, &Options{})
+	}
+
+	assert.Equal(t, expected, writer.String())
+}
+
+func TestShouldHighlightRow(t *testing.T) {
+	tests := []struct {
+		name       string
+		pipes      []*Pipe
+		prevCommit *models.Commit
+		expected   bool
+	}{
+		{
+			name: "no previous commit",
+			pipes: []*Pipe{
+				{fromPos: 0, toPos: 0, fromHash: "selected", toHash: "a2", kind: TERMINATES},
+			},
+			prevCommit: nil,
+			expected:   true,
+		},
+		{
+			name: "previous commit is not selected",
+			pipes: []*Pipe{
+				{fromPos: 0, toPos: 0, fromHash: "a1", toHash: "a2", kind: TERMINATES},
+				{fromPos: 0, toPos: 0, fromHash: "a2", toHash: "a3", kind: STARTS},
+			},
+			prevCommit: &models.Commit{Hash: "a1"},
+			expected:   true,
+		},
+		{
+			name: "selected commit above, terminating in place",
+			pipes: []*Pipe{
+				{fromPos: 0, toPos: 0, fromHash: "selected", toHash: "a2", kind: TERMINATES},
+				{fromPos: 0, toPos: 0, fromHash: "a2", toHash: "a3", kind: STARTS},
+			},
+			prevCommit: &models.Commit{Hash: "selected"},
+			expected:   false,
+		},
+		{
+			name: "selected commit above, terminating in another column",
+			pipes: []*Pipe{
+				{fromPos: 1, toPos: 0, fromHash: "selected", toHash: "a2", kind: TERMINATES},
+				{fromPos: 0, toPos: 0, fromHash: "a2", toHash: "a3", kind: STARTS},
+			},
+			prevCommit: &models.Commit{Hash: "selected"},
+			expected:   true,
+		},
+		{
+			name: "selected commit above, with a real outgoing pipe",
+			pipes: []*Pipe{
+				{fromPos: 0, toPos: 0, fromHash: "selected", toHash: "a2", kind: TERMINATES},
+				{fromPos: 1, toPos: 1, fromHash: "selected", toHash: "b3", kind: CONTINUES},
+			},
+			prevCommit: &models.Commit{Hash: "selected"},
+			expected:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, shouldHighlightRow(test.pipes, "selected", test.prevCommit))
+		})
+	}
+}
+
 func TestGetNextPipes(t *testing.T) {
 	tests := []struct {
 		prevPipes []*Pipe
@@ -517,7 +814,21 @@ func TestGetNextPipes(t *testing.T) {
 				Parents: []string{},
 			},
 			expected: []*Pipe{
-				{fromPos: 1, toPos: 1, fromHash: "root", toHash: models.EmptyTreeCommitHash, kind: STARTS, style: style.FgDefault},
+				{fromPos: 1, toPos: 1, fromHash: "root", toHash: models.EmptyTreeCommitHash, kind: STARTS, style: style.FgDefault, fromRoot: true},
+			},
+		},
+		{
+			prevPipes: []*Pipe{
+				{fromPos: 0, toPos: 0, fromHash: "a", toHash: "b", kind: CONTINUES, style: style.FgDefault},
+				{fromPos: 1, toPos: 1, fromHash: "root", toHash: models.EmptyTreeCommitHash, kind: STARTS, style: style.FgDefault, fromRoot: true},
+			},
+			commit: &models.Commit{
+				Hash:    "b",
+				Parents: []string{"c"},
+			},
+			expected: []*Pipe{
+				{fromPos: 0, toPos: 0, fromHash: "a", toHash: "b", kind: TERMINATES, style: style.FgDefault},
+				{fromPos: 0, toPos: 0, fromHash: "b", toHash: "c", kind: STARTS, style: style.FgDefault},
 			},
 		},
 	}
@@ -527,10 +838,10 @@ func TestGetNextPipes(t *testing.T) {
 
 	for _, test := range tests {
 		getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
-		pipes := getNextPipes(test.prevPipes, test.commit, getStyle)
+		pipes := getNextPipes(test.prevPipes, test.commit, getStyle, &Options{})
 		// rendering cells so that it's easier to see what went wrong
-		actualStr := renderPipeSet(pipes, "selected", nil)
-		expectedStr := renderPipeSet(test.expected, "selected", nil)
+		actualStr := RenderPipeSet(pipes, "selected", nil)
+		expectedStr := RenderPipeSet(test.expected, "selected", nil)
 		t.Log("expected cells:")
 		t.Log(expectedStr)
 		t.Log("actual cells:")
@@ -539,49 +850,1720 @@ func TestGetNextPipes(t *testing.T) {
 	}
 }
 
-func BenchmarkRenderCommitGraph(b *testing.B) {
+func TestGetPipeSetsContinueFromAbove(t *testing.T) {
+	commits := []*models.Commit{
+		{Hash: "1", Parents: []string{"2"}},
+		{Hash: "2", Parents: []string{"3"}},
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+
+	pipeSets := GetPipeSets(commits, getStyle)
+	assert.True(t, ContainsCommitHash(pipeSets[0], "START"))
+
+	pipeSets = GetPipeSetsWithOptions(commits, getStyle, Options{ContinueFromAbove: true})
+	assert.False(t, ContainsCommitHash(pipeSets[0], "START"))
+	assert.EqualValues(t, []*Pipe{
+		{fromPos: 0, toPos: 0, fromHash: "", toHash: "1", kind: TERMINATES, style: style.FgDefault},
+		{fromPos: 0, toPos: 0, fromHash: "1", toHash: "2", kind: STARTS, style: style.FgDefault},
+	}, pipeSets[0])
+}
+
+func TestRenderCommitGraphNonInteractive(t *testing.T) {
 	oldColorLevel := color.ForceSetColorLevel(terminfo.ColorLevelMillions)
 	defer color.ForceSetColorLevel(oldColorLevel)
 
-	commits := generateCommits(50)
-	getStyle := func(commit *models.Commit) style.TextStyle {
-		return authors.AuthorStyle(commit.AuthorName)
+	commits := []*models.Commit{
+		{Hash: "1", Parents: []string{"2", "3"}},
+		{Hash: "3", Parents: []string{"2"}},
+		{Hash: "2", Parents: []string{"4"}},
 	}
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		RenderCommitGraph(commits, "selected", getStyle)
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgRed }
+
+	lines := RenderCommitGraphWithOptions(commits, "", getStyle, Options{NonInteractive: true})
+
+	assert.Equal(t, []string{
+		"M-. ",
+		"| o ",
+		"o-' ",
+	}, lines)
+}
+
+func TestRenderCommitGraphSelectedRowBackground(t *testing.T) {
+	oldColorLevel := color.ForceSetColorLevel(terminfo.ColorLevelMillions)
+	defer color.ForceSetColorLevel(oldColorLevel)
+
+	commits := []*models.Commit{
+		{Hash: "1", Parents: []string{"2", "3"}},
+		{Hash: "3", Parents: []string{"2"}},
+		{Hash: "2", Parents: []string{"4"}},
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+
+	plainLines := RenderCommitGraph(commits, "3", getStyle)
+	lines := RenderCommitGraphWithOptions(commits, "3", getStyle, Options{SelectedRowBackground: &style.BgBlue})
+
+	for i := range lines {
+		assert.Equal(t, utils.Decolorise(plainLines[i]), utils.Decolorise(lines[i]))
 	}
+	assert.NotContains(t, lines[0], "44")
+	// cells other than the commit dot get the background too
+	assert.True(t, strings.HasPrefix(lines[1], style.FgDefault.MergeStyle(style.BgBlue).Sprint("│")))
+	assert.Contains(t, lines[1], highlightStyle.MergeStyle(style.BgBlue).Sprint("◯"))
+	assert.NotContains(t, lines[2], "44")
 }
 
-func generateCommits(count int) []*models.Commit {
-	rnd := rand.New(rand.NewSource(1234))
-	pool := []*models.Commit{{Hash: "a", AuthorName: "A"}}
-	commits := make([]*models.Commit, 0, count)
-	authorPool := []string{"A", "B", "C", "D", "E", "F", "G", "H", "I", "J", "K", "L", "M", "N", "O", "P", "Q", "R", "S", "T", "U", "V", "W", "X", "Y", "Z"}
-	for len(commits) < count {
-		currentCommitIdx := rnd.Intn(len(pool))
-		currentCommit := pool[currentCommitIdx]
-		pool = append(pool[0:currentCommitIdx], pool[currentCommitIdx+1:]...)
-		// I need to pick a random number of parents to add
-		parentCount := rnd.Intn(2) + 1
+func TestRenderCommitGraphStripeBackground(t *testing.T) {
+	oldColorLevel := color.ForceSetColorLevel(terminfo.ColorLevelMillions)
+	defer color.ForceSetColorLevel(oldColorLevel)
 
-		for j := 0; j < parentCount; j++ {
-			reuseParent := rnd.Intn(6) != 1 && j <= len(pool)-1 && j != 0
-			var newParent *models.Commit
-			if reuseParent {
-				newParent = pool[j]
-			} else {
-				newParent = &models.Commit{
-					Hash:       fmt.Sprintf("%s%d", currentCommit.Hash, j),
-					AuthorName: authorPool[rnd.Intn(len(authorPool))],
-				}
-				pool = append(pool, newParent)
-			}
-			currentCommit.Parents = append(currentCommit.Parents, newParent.Hash)
+	commits := []*models.Commit{
+		{Hash: "1", Parents: []string{"2"}},
+		{Hash: "2", Parents: []string{"3", "4"}},
+		{Hash: "4", Parents: []string{"3"}},
+		{Hash: "3", Parents: []string{"5"}},
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+
+	plainLines := RenderCommitGraph(commits, "4", getStyle)
+	lines := RenderCommitGraphWithOptions(commits, "4", getStyle, Options{
+		StripeBackground:      &style.BgBlack,
+		SelectedRowBackground: &style.BgBlue,
+	})
+
+	for i := range lines {
+		assert.Equal(t, utils.Decolorise(plainLines[i]), utils.Decolorise(lines[i]))
+	}
+	assert.Equal(t, plainLines[0], lines[0])
+	// the merge dot keeps its own style on the striped background
+	assert.Contains(t, lines[1], style.FgDefault.MergeStyle(style.BgBlack).Sprint("⏣"))
+	// the selection wins over the stripe
+	assert.Contains(t, lines[2], highlightStyle.MergeStyle(style.BgBlue).Sprint("◯"))
+	// the highlighted pipe from the selected commit stands out on the stripe
+	assert.Contains(t, lines[3], highlightStyle.MergeStyle(style.BgBlack).Sprint("╯"))
+}
+
+func TestRenderCommitGraphRightAlign(t *testing.T) {
+	oldColorLevel := color.ForceSetColorLevel(terminfo.ColorLevelMillions)
+	defer color.ForceSetColorLevel(oldColorLevel)
+
+	commits := []*models.Commit{
+		{Hash: "1", Parents: []string{"2"}},
+		{Hash: "2", Parents: []string{"3", "4"}},
+		{Hash: "4", Parents: []string{"3"}},
+		{Hash: "3", Parents: []string{"5"}},
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+
+	lines := StripStyles(RenderCommitGraphWithOptions(commits, "", getStyle, Options{RightAlign: true}))
+
+	assert.Equal(t, []string{
+		"  ◯ ",
+		"╭─⏣ ",
+		"◯ │ ",
+		"╰─◯ ",
+	}, lines)
+}
+
+func TestRenderRebaseTodoGraph(t *testing.T) {
+	todos := []*models.Commit{
+		{Hash: "1", Action: todo.Pick},
+		{Hash: "2", Action: todo.Fixup},
+		{Hash: "3", Action: todo.Squash},
+		{Hash: "4", Action: todo.Pick},
+		{Hash: "5", Action: todo.Reword},
+		{Hash: "6", Action: todo.Fixup},
+		{Hash: "7", Action: todo.Edit},
+		{Hash: "8", Action: todo.Fixup},
+	}
+
+	lines := StripStyles(RenderRebaseTodoGraph(todos))
+
+	assert.Equal(t, []string{
+		"◯   ",
+		"◯ ╮ ",
+		"◯ │ ",
+		"◯ ╯ ",
+		"◯   ",
+		"◯ ╮ ",
+		"◯ ╯ ",
+		"◯   ",
+	}, lines)
+}
+
+func TestRenderCommitGraphInvisibleStyle(t *testing.T) {
+	commits := []*models.Commit{
+		{Hash: "1", Parents: []string{"2", "3"}},
+		{Hash: "3", Parents: []string{"4"}},
+		{Hash: "2", Parents: []string{"5"}},
+		{Hash: "4", Parents: []string{"5"}},
+		{Hash: "5", Parents: []string{"6"}},
+	}
+	getStyle := func(c *models.Commit) style.TextStyle {
+		if c.Hash == "3" {
+			return InvisibleStyle
 		}
+		return style.FgDefault
+	}
 
-		commits = append(commits, currentCommit)
+	lines := StripStyles(RenderCommitGraphWithOptions(commits, "", getStyle, Options{}))
+
+	assert.Equal(t, []string{
+		"⏣─╮ ",
+		"│   ",
+		"◯   ",
+		"│ ◯ ",
+		"◯─╯ ",
+	}, lines)
+}
+
+func TestRenderCommitGraphUnreachableCommits(t *testing.T) {
+	oldColorLevel := color.ForceSetColorLevel(terminfo.ColorLevelMillions)
+	defer color.ForceSetColorLevel(oldColorLevel)
+
+	commits := []*models.Commit{
+		{Hash: "1", Parents: []string{"2"}},
+		{Hash: "2", Parents: []string{"3"}},
+		{Hash: "3", Parents: []string{"4"}},
 	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgGreen }
+	isReachable := func(hash string) bool { return hash != "2" }
 
-	return commits
+	lines := RenderCommitGraphWithOptions(commits, "", getStyle, Options{IsReachable: isReachable})
+
+	assert.Equal(t, []string{
+		style.FgGreen.Sprint("◯") + " ",
+		unreachableStyle.Sprint("◯") + " ",
+		style.FgGreen.Sprint("◯") + " ",
+	}, lines)
+}
+
+func TestRenderCommitGraphSearchMatches(t *testing.T) {
+	oldColorLevel := color.ForceSetColorLevel(terminfo.ColorLevelMillions)
+	defer color.ForceSetColorLevel(oldColorLevel)
+
+	commits := []*models.Commit{
+		{Hash: "1", Parents: []string{"2"}},
+		{Hash: "2", Parents: []string{"3"}},
+		{Hash: "3", Parents: []string{"4"}},
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgGreen }
+
+	// the selected match keeps the selection highlight
+	lines := RenderCommitGraphWithOptions(commits, "1", getStyle, Options{
+		SearchMatchHashes: set.NewFromSlice([]string{"1", "3"}),
+	})
+
+	assert.Equal(t, []string{
+		highlightStyle.Sprint("◯") + " ",
+		style.FgGreen.Sprint("◯") + " ",
+		searchMatchStyle.Sprint("◯") + " ",
+	}, lines)
+}
+
+func TestRenderCommitGraphFadeBefore(t *testing.T) {
+	oldColorLevel := color.ForceSetColorLevel(terminfo.ColorLevelMillions)
+	defer color.ForceSetColorLevel(oldColorLevel)
+
+	cutoff := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	commits := []*models.Commit{
+		commit("1", parents("2"), timestamp(cutoff.Add(time.Hour))),
+		commit("2", parents("3"), timestamp(cutoff.Add(-time.Hour))),
+		commit("3", parents("4")),
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgGreen }
+
+	lines := RenderCommitGraphWithOptions(commits, "", getStyle, Options{FadeBefore: cutoff})
+
+	assert.Equal(t, []string{
+		style.FgGreen.Sprint("◯") + " ",
+		fadedStyle.Sprint("◯") + " ",
+		// no timestamp, so it counts as recent
+		style.FgGreen.Sprint("◯") + " ",
+	}, lines)
+
+	pipeSets := GetPipeSetsWithOptions(commits, getStyle, Options{FadeBefore: cutoff})
+	assert.Equal(t, fadedStyle, pipeSets[1][1].style)
+
+	// so is the pipe starting from a faded root commit
+	rootCommits := []*models.Commit{commit("1", timestamp(cutoff.Add(-time.Hour)))}
+	pipeSets = GetPipeSetsWithOptions(rootCommits, getStyle, Options{FadeBefore: cutoff})
+	assert.True(t, pipeSets[0][1].fromRoot)
+	assert.Equal(t, fadedStyle, pipeSets[0][1].style)
+}
+
+func TestRenderCommitGraphDownwardStyle(t *testing.T) {
+	oldColorLevel := color.ForceSetColorLevel(terminfo.ColorLevelMillions)
+	defer color.ForceSetColorLevel(oldColorLevel)
+
+	commits := []*models.Commit{
+		commit("1", parents("2", "3")),
+		commit("3", parents("2")),
+		commit("2"),
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgGreen }
+	downwardStyle := func(textStyle style.TextStyle) style.TextStyle { return style.FgCyan }
+
+	lines := RenderCommitGraphWithOptions(commits, "", getStyle, Options{DownwardStyle: downwardStyle})
+
+	assert.Equal(t, []string{
+		style.FgGreen.Sprint("⏣") + style.FgCyan.Sprint("─") + style.FgCyan.Sprint("╮") + " ",
+		style.FgGreen.Sprint("│") + " " + style.FgGreen.Sprint("◯") + " ",
+		style.FgGreen.Sprint("◯") + style.FgGreen.Sprint("─") + style.FgGreen.Sprint("╯") + " ",
+	}, lines)
+
+	// without the option, everything keeps the commits' style
+	lines = RenderCommitGraphWithOptions(commits, "", getStyle, Options{})
+	assert.Equal(t, style.FgGreen.Sprint("⏣")+style.FgGreen.Sprint("─")+style.FgGreen.Sprint("╮")+" ", lines[0])
+}
+
+func TestRenderCommitGraphBySelectedIndex(t *testing.T) {
+	oldColorLevel := color.ForceSetColorLevel(terminfo.ColorLevelMillions)
+	defer color.ForceSetColorLevel(oldColorLevel)
+
+	commits := []*models.Commit{
+		commit("1", parents("2", "3")),
+		commit("3", parents("2")),
+		commit("2"),
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+
+	assert.Equal(t, RenderCommitGraph(commits, "3", getStyle), RenderCommitGraphBySelectedIndex(commits, 1, getStyle))
+
+	for _, index := range []int{-1, 3} {
+		assert.Equal(t, RenderCommitGraph(commits, "", getStyle), RenderCommitGraphBySelectedIndex(commits, index, getStyle))
+	}
+	assert.Empty(t, RenderCommitGraphBySelectedIndex(nil, 0, getStyle))
+}
+
+func TestRenderCommitGraphAuthorColoredDots(t *testing.T) {
+	oldColorLevel := color.ForceSetColorLevel(terminfo.ColorLevelMillions)
+	defer color.ForceSetColorLevel(oldColorLevel)
+
+	author := func(name string) commitOption {
+		return func(c *models.Commit) { c.AuthorName = name }
+	}
+	commits := []*models.Commit{
+		commit("1", parents("2", "3"), author("Jane Smith")),
+		commit("3", parents("2"), author("John Doe")),
+		commit("2", author("Jane Smith")),
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgGreen }
+
+	lines := RenderCommitGraphWithOptions(commits, "", getStyle, Options{AuthorColoredDots: true})
+
+	janeStyle := authors.AuthorStyle("Jane Smith")
+	johnStyle := authors.AuthorStyle("John Doe")
+	assert.Equal(t, []string{
+		janeStyle.Sprint("⏣") + neutralPipeStyle.Sprint("─") + neutralPipeStyle.Sprint("╮") + " ",
+		neutralPipeStyle.Sprint("│") + " " + johnStyle.Sprint("◯") + " ",
+		janeStyle.Sprint("◯") + neutralPipeStyle.Sprint("─") + neutralPipeStyle.Sprint("╯") + " ",
+	}, lines)
+}
+
+func TestRenderCommitGraphAuthorColoredDotsInvisibleStyle(t *testing.T) {
+	commits := []*models.Commit{
+		{Hash: "1", Parents: []string{"2", "3"}},
+		{Hash: "3", Parents: []string{"4"}},
+		{Hash: "2", Parents: []string{"5"}},
+		{Hash: "4", Parents: []string{"5"}},
+		{Hash: "5", Parents: []string{"6"}},
+	}
+	getStyle := func(c *models.Commit) style.TextStyle {
+		if c.Hash == "3" {
+			return InvisibleStyle
+		}
+		return style.FgDefault
+	}
+
+	lines := StripStyles(RenderCommitGraphWithOptions(commits, "", getStyle, Options{AuthorColoredDots: true}))
+
+	assert.Equal(t, []string{
+		"⏣─╮ ",
+		"│   ",
+		"◯   ",
+		"│ ◯ ",
+		"◯─╯ ",
+	}, lines)
+}
+
+func TestRenderCommitGraphBasicColors(t *testing.T) {
+	oldColorLevel := color.ForceSetColorLevel(terminfo.ColorLevelMillions)
+	defer color.ForceSetColorLevel(oldColorLevel)
+
+	commits := []*models.Commit{
+		commit("1", parents("2", "3")),
+		commit("3", parents("2")),
+		commit("2", parents("4")),
+	}
+	rgbStyle := style.New().SetFg(style.NewRGBColor(color.Rgb(250, 10, 10)))
+	getStyle := func(c *models.Commit) style.TextStyle { return rgbStyle }
+
+	lines := RenderCommitGraphWithOptions(commits, "", getStyle, Options{BasicColors: true})
+
+	assert.Equal(t, []string{
+		style.FgRed.Sprint("⏣") + style.FgRed.Sprint("─") + style.FgRed.Sprint("╮") + " ",
+		style.FgRed.Sprint("│") + " " + style.FgRed.Sprint("◯") + " ",
+		style.FgRed.Sprint("◯") + style.FgRed.Sprint("─") + style.FgRed.Sprint("╯") + " ",
+	}, lines)
+}
+
+func TestRenderCommitGraphPreviousHash(t *testing.T) {
+	oldColorLevel := color.ForceSetColorLevel(terminfo.ColorLevelMillions)
+	defer color.ForceSetColorLevel(oldColorLevel)
+
+	commits := []*models.Commit{
+		commit("1", parents("2")),
+		commit("2", parents("3")),
+		commit("3", parents("4")),
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgGreen }
+
+	lines := RenderCommitGraphWithOptions(commits, "1", getStyle, Options{PreviousHash: "3"})
+
+	assert.Equal(t, []string{
+		highlightStyle.Sprint("◯") + " ",
+		style.FgGreen.Sprint("◯") + " ",
+		previousHashStyle.Sprint("◯") + " ",
+	}, lines)
+
+	// the selection wins
+	lines = RenderCommitGraphWithOptions(commits, "1", getStyle, Options{PreviousHash: "1"})
+	assert.Equal(t, highlightStyle.Sprint("◯")+" ", lines[0])
+}
+
+func TestRenderCommitGraphWIPCommit(t *testing.T) {
+	oldColorLevel := color.ForceSetColorLevel(terminfo.ColorLevelMillions)
+	defer color.ForceSetColorLevel(oldColorLevel)
+
+	// HEAD isn't necessarily the first commit, e.g. with `git log --all`
+	commits := []*models.Commit{
+		{Hash: "a", Parents: []string{"2"}},
+		{Hash: "1", Parents: []string{"2"}},
+		{Hash: "2", Parents: []string{"3"}},
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgGreen }
+
+	pipeSets := GetPipeSetsWithOptions(commits, getStyle, Options{WIPParentHash: "1"})
+	assert.Len(t, pipeSets, len(commits)+1)
+
+	lines := RenderCommitGraphWithOptions(commits, "", getStyle, Options{WIPParentHash: "1"})
+	assert.Equal(t, dashedStyle.Sprint("◌")+" ", lines[0])
+	assert.Equal(t, []string{
+		"◌ ",
+		"│ ◯ ",
+		"◯ │ ",
+		"◯─╯ ",
+	}, StripStyles(lines))
+
+	// with a clean working tree there's no WIP commit
+	assert.Equal(t,
+		RenderCommitGraph(commits, "", getStyle),
+		RenderCommitGraphWithOptions(commits, "", getStyle, Options{WIPParentHash: ""}),
+	)
+}
+
+func TestGetPipeSetsDepthLimit(t *testing.T) {
+	commits := []*models.Commit{
+		{Hash: "1", Parents: []string{"2", "3"}},
+		{Hash: "3", Parents: []string{"2"}},
+		{Hash: "2", Parents: []string{"4"}},
+		{Hash: "4", Parents: []string{"5"}},
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+
+	pipeSets := GetPipeSetsWithOptions(commits, getStyle, Options{DepthLimit: 2})
+	assert.Len(t, pipeSets, len(commits))
+	assert.Equal(t, GetPipeSets(commits, getStyle)[:2], pipeSets[:2])
+	assert.Empty(t, pipeSets[2])
+	assert.Empty(t, pipeSets[3])
+
+	lines := StripStyles(RenderAux(pipeSets, commits, ""))
+	assert.Equal(t, []string{
+		"⏣─╮ ",
+		"│ ◯ ",
+		"  ",
+		"  ",
+	}, lines)
+}
+
+func TestTopicCollapser(t *testing.T) {
+	commits := []*models.Commit{
+		{Hash: "1", Name: "merge", Parents: []string{"2", "5"}},
+		{Hash: "5", Name: "topic tip", Parents: []string{"4"}},
+		{Hash: "4", Name: "topic start", Parents: []string{"3"}},
+		{Hash: "2", Name: "main", Parents: []string{"3"}},
+		{Hash: "3", Name: "base", Parents: []string{"6"}},
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+
+	collapser := NewTopicCollapser()
+	collapsed, hidden := collapser.Collapse(commits)
+
+	assert.Equal(t, []string{"1", "5", "2", "3"}, lo.Map(collapsed, func(c *models.Commit, _ int) string { return c.Hash }))
+	assert.Equal(t, []string{"3"}, collapsed[1].Parents)
+	assert.Equal(t, "topic tip (+1)", collapsed[1].Name)
+	assert.Equal(t, map[string][]*models.Commit{"5": {commits[1], commits[2]}}, hidden)
+	// the original commits are left alone
+	assert.Equal(t, []string{"4"}, commits[1].Parents)
+
+	opts := Options{CollapsedHashes: set.NewFromSlice(lo.Keys(hidden))}
+	assert.Equal(t, []string{
+		"⏣─╮ ",
+		"│ ◉ ",
+		"◯ │ ",
+		"◯─╯ ",
+	}, StripStyles(RenderCommitGraphWithOptions(collapsed, "", getStyle, opts)))
+
+	collapser.Toggle("1")
+	assert.True(t, collapser.IsExpanded("1"))
+	expanded, hidden := collapser.Collapse(commits)
+	assert.Equal(t, commits, expanded)
+	assert.Empty(t, hidden)
+
+	collapser.Toggle("1")
+	assert.False(t, collapser.IsExpanded("1"))
+}
+
+func TestAssignBranchColors(t *testing.T) {
+	commits := []*models.Commit{
+		{Hash: "1", Parents: []string{"2", "4"}},
+		{Hash: "4", Parents: []string{"3"}},
+		{Hash: "a", Parents: []string{"2"}},
+		{Hash: "2", Parents: []string{"3"}},
+		{Hash: "3", Parents: []string{"5"}},
+	}
+	palette := []style.TextStyle{style.FgRed, style.FgGreen}
+
+	colors := AssignBranchColors(commits, palette)
+	assert.Equal(t, map[string]style.TextStyle{
+		"1": style.FgRed,
+		"2": style.FgRed,
+		"3": style.FgRed,
+		"5": style.FgRed,
+		"4": style.FgGreen,
+		// the palette wraps around
+		"a": style.FgRed,
+	}, colors)
+
+	// the same commits always give the same colors, even with more commits loaded
+	assert.Equal(t, colors, AssignBranchColors(commits, palette))
+	moreCommits := append(slices.Clone(commits), &models.Commit{Hash: "5", Parents: []string{"6"}})
+	for hash, color := range colors {
+		assert.Equal(t, color, AssignBranchColors(moreCommits, palette)[hash])
+	}
+
+	assert.Empty(t, AssignBranchColors(commits, nil))
+}
+
+func TestRenderCommitGraphOnlyMerges(t *testing.T) {
+	commits := []*models.Commit{
+		{Hash: "1", Parents: []string{"2", "4"}},
+		{Hash: "4", Parents: []string{"3"}},
+		{Hash: "2", Parents: []string{"6"}},
+		{Hash: "6", Parents: []string{"3"}},
+		{Hash: "3", Parents: []string{"7"}},
+		{Hash: "7", Parents: []string{"8"}},
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+
+	lines := StripStyles(RenderCommitGraphWithOptions(commits, "", getStyle, Options{OnlyMerges: true}))
+	assert.Equal(t, []string{
+		"⏣─╮ ",
+		"│ │ ",
+		"│ │ ",
+		"│ │ ",
+		"│─╯ ",
+		"│ ",
+	}, lines)
+
+	// a run of hidden commits is passed through by a single pipe from the merge
+	pipeSets := GetPipeSetsWithOptions(commits, getStyle, Options{OnlyMerges: true})
+	assert.Equal(t, []*Pipe{
+		{fromPos: 0, toPos: 0, fromHash: "1", toHash: "6", kind: CONTINUES, style: style.FgDefault},
+		{fromPos: 1, toPos: 1, fromHash: "1", toHash: "3", kind: CONTINUES, style: style.FgDefault},
+	}, pipeSets[2])
+}
+
+func TestRenderCommitGraphChangeMagnitudes(t *testing.T) {
+	commits := []*models.Commit{
+		{Hash: "1", Parents: []string{"2", "3"}},
+		{Hash: "3", Parents: []string{"2"}},
+		{Hash: "2", Parents: []string{"4"}},
+		{Hash: "4", Parents: []string{"5"}},
+		{Hash: "5", Parents: []string{"6"}},
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+	magnitudes := map[string]int{"1": 5000, "3": 99, "2": 100, "4": 1000}
+
+	tests := []struct {
+		name       string
+		thresholds []int
+		expected   []string
+	}{
+		{
+			name: "default thresholds",
+			// merges keep their own symbol, and commits without a count are drawn as usual
+			expected: []string{"⏣─╮ ", "│ ◯ ", "◍─╯ ", "● ", "◯ "},
+		},
+		{
+			name:       "custom thresholds",
+			thresholds: []int{50, 99, 10000},
+			expected:   []string{"⏣─╮ ", "│ ● ", "●─╯ ", "● ", "◯ "},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts := Options{ChangeMagnitudes: magnitudes, MagnitudeThresholds: test.thresholds}
+			lines := StripStyles(RenderCommitGraphWithOptions(commits, "", getStyle, opts))
+			assert.Equal(t, test.expected, lines)
+
+			// the pipes are laid out the same regardless of the magnitudes
+			assert.Equal(t, GetPipeSets(commits, getStyle), GetPipeSetsWithOptions(commits, getStyle, opts))
+		})
+	}
+}
+
+func TestRenderCommitGraphInRangeHashes(t *testing.T) {
+	commits := []*models.Commit{
+		{Hash: "1", Parents: []string{"2"}},
+		{Hash: "2", Parents: []string{"3", "4"}},
+		{Hash: "4", Parents: []string{"3"}},
+		{Hash: "3", Parents: []string{"5"}},
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+	opts := Options{
+		InRangeHashes: set.NewFromSlice([]string{"2", "4"}),
+		// ignored in favour of the range
+		ChangeMagnitudes: map[string]int{"1": 5000},
+	}
+
+	assert.Equal(t, []string{
+		"◯ ",
+		"⏣─╮ ",
+		"│ ● ",
+		"◯─╯ ",
+	}, StripStyles(RenderCommitGraphWithOptions(commits, "", getStyle, opts)))
+	assert.Equal(t, GetPipeSets(commits, getStyle), GetPipeSetsWithOptions(commits, getStyle, opts))
+}
+
+func TestRenderCommitGraphBraille(t *testing.T) {
+	commits := []*models.Commit{
+		{Hash: "1", Parents: []string{"2"}},
+		{Hash: "2", Parents: []string{"3", "4"}},
+		{Hash: "4", Parents: []string{"3"}},
+		{Hash: "3", Parents: []string{"5"}},
+		{Hash: "5", Parents: []string{"6"}},
+		{Hash: "6", Parents: []string{"7"}},
+		{Hash: "7"},
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+
+	lines := StripStyles(RenderCommitGraphWithOptions(commits, "", getStyle, Options{Braille: true}))
+	assert.Equal(t, []string{
+		// the merge falls back to normal rendering, along with the row it's paired with
+		"◯ ",
+		"⏣─╮ ",
+		"│ ◯ ",
+		"◯─╯ ",
+		// two commits in one line
+		"⣿ ",
+		// the last row is on its own, in the upper half
+		"⠛ ",
+	}, lines)
+
+	// a commit in the lower half below a pipe just passing through in the upper half
+	pipeSets := [][]*Pipe{
+		{{fromPos: 0, toPos: 0, fromHash: "a", toHash: "c", kind: CONTINUES, style: style.FgDefault}},
+		{
+			{fromPos: 0, toPos: 0, fromHash: "a", toHash: "c", kind: TERMINATES, style: style.FgDefault},
+			{fromPos: 0, toPos: 0, fromHash: "c", toHash: "d", kind: STARTS, style: style.FgDefault},
+		},
+	}
+	assert.Equal(t, "⣧ ", utils.Decolorise(renderBraillePair(pipeSets, []*models.Commit{{Hash: "b"}, {Hash: "c"}}, "")))
+}
+
+func TestRenderCommitGraphHeadInFirstColumn(t *testing.T) {
+	commits := []*models.Commit{
+		{Hash: "b2", Parents: []string{"b1"}},
+		{Hash: "b1", Parents: []string{"h2"}},
+		{Hash: "h1", Parents: []string{"h2", "m1"}},
+		{Hash: "m1", Parents: []string{"h3"}},
+		{Hash: "h2", Parents: []string{"h3"}},
+		{Hash: "h3"},
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+
+	assert.Equal(t, []string{
+		"◯ ",
+		"◯ ",
+		"│ ⏣─╮ ",
+		"│ │ ◯ ",
+		"◯─╯ │ ",
+		"◯───╯ ",
+	}, StripStyles(RenderCommitGraph(commits, "", getStyle)))
+
+	assert.Equal(t, []string{
+		"  ◯ ",
+		"  ◯ ",
+		"⏣─│─╮ ",
+		"│ │ ◯ ",
+		"◯─╯ │ ",
+		"◯───╯ ",
+	}, StripStyles(RenderCommitGraphWithOptions(commits, "", getStyle, Options{HeadHash: "h1"})))
+}
+
+func TestStripStyles(t *testing.T) {
+	oldColorLevel := color.ForceSetColorLevel(terminfo.ColorLevelMillions)
+	defer color.ForceSetColorLevel(oldColorLevel)
+
+	commits := generateCommits(50)
+	getStyle := func(commit *models.Commit) style.TextStyle {
+		return authors.AuthorStyle(commit.AuthorName)
+	}
+
+	pipeSets := GetPipeSets(commits, getStyle)
+	lines := StripStyles(RenderAux(pipeSets, commits, "a0"))
+
+	for i, line := range lines {
+		cellCount := lo.Max(lo.Map(pipeSets[i], func(pipe *Pipe, _ int) int { return pipe.right() })) + 1
+		assert.NotContains(t, line, "\x1b")
+		// each cell is two characters wide
+		assert.Equal(t, cellCount*2, utf8.RuneCountInString(line))
+	}
+}
+
+func TestGetPipeSetsWithMergeBase(t *testing.T) {
+	commits := []*models.Commit{
+		{Hash: "1", Parents: []string{"2", "3"}},
+		{Hash: "3", Parents: []string{"4"}},
+		{Hash: "2", Parents: []string{"4"}},
+		{Hash: "4", Parents: []string{"5"}},
+		{Hash: "5", Parents: []string{"6"}},
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+
+	pipeSets := GetPipeSetsWithOptions(commits, getStyle, Options{MergeBaseHash: "4"})
+
+	startingPipeStyles := map[string]style.TextStyle{}
+	for _, pipeSet := range pipeSets {
+		for _, pipe := range pipeSet {
+			if pipe.kind == STARTS {
+				startingPipeStyles[pipe.fromHash+"->"+pipe.toHash] = pipe.style
+			}
+		}
+	}
+
+	assert.Equal(t, map[string]style.TextStyle{
+		"1->2": mergeBaseLineageStyle,
+		"1->3": mergeBaseLineageStyle,
+		"3->4": mergeBaseLineageStyle,
+		"2->4": mergeBaseLineageStyle,
+		"4->5": style.FgDefault,
+		"5->6": style.FgDefault,
+	}, startingPipeStyles)
+}
+
+func TestRenderCommitGraphConcurrency(t *testing.T) {
+	oldColorLevel := color.ForceSetColorLevel(terminfo.ColorLevelMillions)
+	defer color.ForceSetColorLevel(oldColorLevel)
+	defer SetMaxRenderConcurrency(0)
+	// so that the few commits below are still split up between goroutines
+	SetMinRowsPerRenderGoroutine(1)
+	defer SetMinRowsPerRenderGoroutine(0)
+
+	oldMaxProcs := runtime.GOMAXPROCS(4)
+	defer runtime.GOMAXPROCS(oldMaxProcs)
+
+	commits := generateCommits(50)
+	getStyle := func(commit *models.Commit) style.TextStyle {
+		return authors.AuthorStyle(commit.AuthorName)
+	}
+
+	SetMaxRenderConcurrency(1)
+	sequentialLines := RenderCommitGraph(commits, "a0", getStyle)
+
+	SetMaxRenderConcurrency(4)
+	parallelLines := RenderCommitGraph(commits, "a0", getStyle)
+
+	assert.Len(t, sequentialLines, len(commits))
+	assert.Equal(t, sequentialLines, parallelLines)
+}
+
+func BenchmarkRenderCommitGraph(b *testing.B) {
+	oldColorLevel := color.ForceSetColorLevel(terminfo.ColorLevelMillions)
+	defer color.ForceSetColorLevel(oldColorLevel)
+
+	commits := generateCommits(50)
+	getStyle := func(commit *models.Commit) style.TextStyle {
+		return authors.AuthorStyle(commit.AuthorName)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		RenderCommitGraph(commits, "selected", getStyle)
+	}
+}
+
+func TestRenderConcurrency(t *testing.T) {
+	defer SetMinRowsPerRenderGoroutine(0)
+
+	oldMaxProcs := runtime.GOMAXPROCS(4)
+	defer runtime.GOMAXPROCS(oldMaxProcs)
+
+	assert.Equal(t, 1, renderConcurrency(0))
+	assert.Equal(t, 1, renderConcurrency(10))
+	assert.Equal(t, 1, renderConcurrency(999))
+	assert.Equal(t, 2, renderConcurrency(1000))
+	assert.Equal(t, 4, renderConcurrency(100000))
+
+	SetMinRowsPerRenderGoroutine(10)
+	assert.Equal(t, 1, renderConcurrency(10))
+	assert.Equal(t, 3, renderConcurrency(30))
+}
+
+func BenchmarkRenderCommitGraphBySize(b *testing.B) {
+	oldColorLevel := color.ForceSetColorLevel(terminfo.ColorLevelMillions)
+	defer color.ForceSetColorLevel(oldColorLevel)
+
+	getStyle := func(commit *models.Commit) style.TextStyle {
+		return authors.AuthorStyle(commit.AuthorName)
+	}
+	for _, size := range []int{10, 100, 1000, 2000} {
+		commits := generateCommits(size)
+		b.Run(fmt.Sprintf("%d commits", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				RenderCommitGraph(commits, "selected", getStyle)
+			}
+		})
+	}
+}
+
+func BenchmarkGetNextPipes(b *testing.B) {
+	commits := generateCommits(500)
+	getStyle := func(commit *models.Commit) style.TextStyle {
+		return style.FgDefault
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pipes := []*Pipe{{fromPos: 0, toPos: 0, fromHash: "START", toHash: commits[0].Hash, kind: STARTS, style: style.FgDefault}}
+		for _, commit := range commits {
+			pipes = getNextPipes(pipes, commit, getStyle, &Options{})
+		}
+	}
+}
+
+func generateCommits(count int) []*models.Commit {
+	rnd := rand.New(rand.NewSource(1234))
+	pool := []*models.Commit{{Hash: "a", AuthorName: "A"}}
+	commits := make([]*models.Commit, 0, count)
+	authorPool := []string{"A", "B", "C", "D", "E", "F", "G", "H", "I", "J", "K", "L", "M", "N", "O", "P", "Q", "R", "S", "T", "U", "V", "W", "X", "Y", "Z"}
+	for len(commits) < count {
+		currentCommitIdx := rnd.Intn(len(pool))
+		currentCommit := pool[currentCommitIdx]
+		pool = append(pool[0:currentCommitIdx], pool[currentCommitIdx+1:]...)
+		// I need to pick a random number of parents to add
+		parentCount := rnd.Intn(2) + 1
+
+		for j := 0; j < parentCount; j++ {
+			reuseParent := rnd.Intn(6) != 1 && j <= len(pool)-1 && j != 0
+			var newParent *models.Commit
+			if reuseParent {
+				newParent = pool[j]
+			} else {
+				newParent = &models.Commit{
+					Hash:       fmt.Sprintf("%s%d", currentCommit.Hash, j),
+					AuthorName: authorPool[rnd.Intn(len(authorPool))],
+				}
+				pool = append(pool, newParent)
+			}
+			currentCommit.Parents = append(currentCommit.Parents, newParent.Hash)
+		}
+
+		commits = append(commits, currentCommit)
+	}
+
+	return commits
+}
+
+func TestDiffRenderedGraphs(t *testing.T) {
+	before := []string{
+		"◯ ",
+		"⏣─╮ ",
+		"│ ◯ ",
+		"◯─╯ ",
+	}
+
+	assert.Equal(t, "", DiffRenderedGraphs(before, before))
+
+	after := []string{
+		"◯ ",
+		"⏣─╮ ",
+		"◯ │ ",
+		"◯─╯ ",
+		"◯ ",
+	}
+
+	expected := `row 2:
+- │ ◯ 
++ ◯ │ 
+  ^^^^
+row 4:
++ ◯ 
+`
+	assert.Equal(t, expected, DiffRenderedGraphs(before, after))
+}
+
+func TestRenderLaneLabels(t *testing.T) {
+	pipes := []*Pipe{
+		{fromPos: 0, toPos: 0, fromHash: "a", toHash: "b", kind: STARTS},
+		{fromPos: 0, toPos: 1, fromHash: "a", toHash: "c", kind: STARTS},
+		{fromPos: 2, toPos: 2, fromHash: "x", toHash: "y", kind: CONTINUES},
+		{fromPos: 3, toPos: 3, fromHash: "u", toHash: "v", kind: CONTINUES},
+		{fromPos: 4, toPos: 0, fromHash: "z", toHash: "a", kind: TERMINATES},
+	}
+	branchNames := map[string]string{"a": "main", "x": "feature", "z": "topic"}
+
+	assert.Equal(t, "m   f   ", RenderLaneLabels(pipes, branchNames, Options{}))
+	assert.Equal(t, "mai     fea     ", RenderLaneLabels(pipes, branchNames, Options{ColumnGap: 1}))
+	assert.Equal(t, "", RenderLaneLabels(pipes[4:], branchNames, Options{}))
+}
+
+func TestRenderCommitGraphWithStyles(t *testing.T) {
+	commits := []*models.Commit{
+		{Hash: "1", Parents: []string{"2", "3"}},
+		{Hash: "3", Parents: []string{"2"}},
+		{Hash: "2"},
+	}
+	styles := map[string]style.TextStyle{"3": style.FgRed}
+
+	expected := RenderCommitGraph(commits, "", func(c *models.Commit) style.TextStyle {
+		if c.Hash == "3" {
+			return style.FgRed
+		}
+		return style.FgDefault
+	})
+	assert.Equal(t, expected, RenderCommitGraphWithStyles(commits, "", styles))
+}
+
+func TestGetPipeSetsOnWidthExceeded(t *testing.T) {
+	// three lanes side by side
+	commits := []*models.Commit{
+		{Hash: "1", Parents: []string{"2", "3", "4"}},
+		{Hash: "2", Parents: []string{"5"}},
+		{Hash: "3", Parents: []string{"5"}},
+		{Hash: "4", Parents: []string{"5"}},
+		{Hash: "5"},
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+
+	widths := []int{}
+	onWidthExceeded := func(width int) { widths = append(widths, width) }
+
+	GetPipeSetsWithOptions(commits, getStyle, Options{OnWidthExceeded: onWidthExceeded, WidthThreshold: 3})
+	assert.Equal(t, []int{}, widths)
+
+	GetPipeSetsWithOptions(commits, getStyle, Options{OnWidthExceeded: onWidthExceeded, WidthThreshold: 1})
+	assert.Equal(t, []int{3}, widths)
+}
+
+// builds commits ordered children-first from arbitrary bytes: the first byte
+// gives the number of commits, and each further byte gives either the number
+// of parents of the next commit or which later commit the next parent is
+func fuzzCommits(data []byte) []*models.Commit {
+	if len(data) == 0 {
+		return nil
+	}
+
+	count := 1 + int(data[0])%24
+	data = data[1:]
+	next := func() int {
+		if len(data) == 0 {
+			return 0
+		}
+		b := data[0]
+		data = data[1:]
+		return int(b)
+	}
+
+	commits := make([]*models.Commit, count)
+	for i := range commits {
+		commits[i] = &models.Commit{Hash: fmt.Sprintf("%d", i)}
+		if i == count-1 {
+			continue
+		}
+		parentCount := next() % 4
+		for range parentCount {
+			parent := fmt.Sprintf("%d", i+1+next()%(count-i-1))
+			if !lo.Contains(commits[i].Parents, parent) {
+				commits[i].Parents = append(commits[i].Parents, parent)
+			}
+		}
+	}
+	return commits
+}
+
+func FuzzGetNextPipes(f *testing.F) {
+	f.Add([]byte{5, 2, 0, 1, 1, 0, 1, 0})
+	f.Add([]byte{8, 3, 0, 1, 2, 1, 3, 1, 0, 2, 1, 4, 0, 1, 0})
+	f.Add([]byte{12, 0, 0, 2, 3, 5, 1, 7, 3, 0, 1, 2, 2, 6, 1, 0, 1, 1, 3, 2, 0, 4})
+
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		commits := fuzzCommits(data)
+		if len(commits) == 0 {
+			return
+		}
+
+		opts := &Options{}
+		pipes := []*Pipe{{fromPos: 0, toPos: 0, fromHash: "START", toHash: commits[0].Hash, kind: STARTS, style: style.FgDefault}}
+		for _, commit := range commits {
+			pipes = getNextPipes(pipes, commit, getStyle, opts)
+
+			pipesByPos := map[int]*Pipe{}
+			for _, pipe := range pipes {
+				if pipe.kind == TERMINATES {
+					continue
+				}
+				if other, ok := pipesByPos[pipe.toPos]; ok && other.toHash != pipe.toHash {
+					t.Fatalf("commit %s: pipes to %s and %s both end on column %d", commit.Hash, other.toHash, pipe.toHash, pipe.toPos)
+				}
+				pipesByPos[pipe.toPos] = pipe
+			}
+
+			commitPipe, ok := lo.Find(pipes, func(pipe *Pipe) bool {
+				return pipe.kind == STARTS && pipe.fromHash == commit.Hash
+			})
+			if !ok {
+				t.Fatalf("commit %s: no pipe starts from it", commit.Hash)
+			}
+			if maxPos := pipeSetWidth(pipes) - 1; commitPipe.fromPos < 0 || commitPipe.fromPos > maxPos {
+				t.Fatalf("commit %s: position %d is outside of [0, %d]", commit.Hash, commitPipe.fromPos, maxPos)
+			}
+		}
+	})
+}
+
+func TestRenderCommitGraphJunctionGlyphs(t *testing.T) {
+	commits := []*models.Commit{
+		{Hash: "1", Parents: []string{"2"}},
+		{Hash: "2", Parents: []string{"3", "5"}},
+		{Hash: "3", Parents: []string{"4"}},
+		{Hash: "4", Parents: []string{"5"}},
+		{Hash: "5"},
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+
+	lines := StripStyles(RenderCommitGraphWithOptions(commits, "", getStyle, Options{JunctionGlyphs: true}))
+
+	assert.Equal(t, []string{
+		"◯ ",
+		"◆─╮ ",
+		"◯ │ ",
+		"◯ │ ",
+		"◯─╯ ",
+	}, lines)
+}
+
+func TestRenderCommitGraphCornerStyle(t *testing.T) {
+	commits := []*models.Commit{
+		{Hash: "1", Parents: []string{"2", "3"}},
+		{Hash: "2", Parents: []string{"4", "5"}},
+		{Hash: "3", Parents: []string{"4"}},
+		{Hash: "4", Parents: []string{"5"}},
+		{Hash: "5"},
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+
+	rounded := StripStyles(RenderCommitGraphWithOptions(commits, "", getStyle, Options{CornerStyle: RoundedCorners}))
+	assert.Equal(t, StripStyles(RenderCommitGraph(commits, "", getStyle)), rounded)
+
+	sharp := StripStyles(RenderCommitGraphWithOptions(commits, "", getStyle, Options{CornerStyle: SharpCorners}))
+	assert.Equal(t, []string{
+		"⏣─┐ ",
+		"⏣─│─┐ ",
+		"│ ◯ │ ",
+		"◯─┘ │ ",
+		"◯───┘ ",
+	}, sharp)
+
+	nonInteractive := RenderCommitGraphWithOptions(commits, "", getStyle, Options{CornerStyle: SharpCorners, NonInteractive: true})
+	assert.Equal(t, RenderCommitGraphWithOptions(commits, "", getStyle, Options{NonInteractive: true}), nonInteractive)
+}
+
+func TestRenderCommitGraphRouteSelectedLineage(t *testing.T) {
+	commits := []*models.Commit{
+		{Hash: "1", Parents: []string{"2", "4"}},
+		{Hash: "2", Parents: []string{"3"}},
+		{Hash: "4", Parents: []string{"5"}},
+		{Hash: "3", Parents: []string{"6"}},
+		{Hash: "5", Parents: []string{"6"}},
+		{Hash: "6"},
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+
+	assert.Equal(t, []string{
+		"⏣─╮ ",
+		"◯ │ ",
+		"│ ◯ ",
+		"◯ │ ",
+		"│ ◯ ",
+		"◯─╯ ",
+	}, StripStyles(RenderCommitGraph(commits, "4", getStyle)))
+
+	// the lineage of 4 moves to the first column, pushing the other lanes to
+	// the right, and is drawn highlighted all the way down
+	lines := RenderCommitGraphWithOptions(commits, "4", getStyle, Options{RouteSelectedLineage: true})
+	assert.Equal(t, []string{
+		"  ⏣─╮ ",
+		"  ◯ │ ",
+		"◯ │─╯ ",
+		"│ ◯ ",
+		"◯ │ ",
+		"◯─╯ ",
+	}, StripStyles(lines))
+	assert.True(t, strings.HasPrefix(lines[3], highlightStyle.Sprint("│")))
+	assert.True(t, strings.HasPrefix(lines[4], highlightStyle.Sprint("◯")))
+	assert.False(t, strings.Contains(lines[1], highlightStyle.Sprint("◯")))
+
+	// without a selection, it has no effect
+	assert.Equal(t,
+		RenderCommitGraph(commits, "", getStyle),
+		RenderCommitGraphWithOptions(commits, "", getStyle, Options{RouteSelectedLineage: true}))
+}
+
+func TestRenderCommitGraphMaxLanes(t *testing.T) {
+	commits := []*models.Commit{
+		{Hash: "a", Parents: []string{"x"}},
+		{Hash: "b", Parents: []string{"r"}},
+		{Hash: "c", Parents: []string{"r"}},
+		{Hash: "d", Parents: []string{"r"}},
+		{Hash: "e", Parents: []string{"r"}},
+		{Hash: "x", Parents: []string{"r"}},
+		{Hash: "r"},
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+
+	assert.Equal(t, []string{
+		"◯ ",
+		"│ ◯ ",
+		"│ │ ◯ ",
+		"│ │ │ ◯ ",
+		"│ │ │ │ ◯ ",
+		"◯ │ │ │ │ ",
+		"◯─┴─┴─┴─╯ ",
+	}, StripStyles(RenderCommitGraph(commits, "", getStyle)))
+
+	assert.Equal(t, []string{
+		"◯ ",
+		"│ ◯ ",
+		"│ │ ◯ ",
+		"│ │ ◯1",
+		"│ │ ◯2",
+		"◯ │ ⋯2",
+		"◯─┴─╯ ",
+	}, StripStyles(RenderCommitGraphWithOptions(commits, "", getStyle, Options{MaxLanes: 3})))
+
+	assert.Equal(t, []string{
+		"o ",
+		"| o ",
+		"| | o ",
+		"| | o1",
+		"| | o2",
+		"o | ~2",
+		"o-+-' ",
+	}, RenderCommitGraphWithOptions(commits, "", getStyle, Options{MaxLanes: 3, NonInteractive: true}))
+
+	for _, pipes := range GetPipeSetsWithOptions(commits, getStyle, Options{MaxLanes: 3}) {
+		assert.LessOrEqual(t, pipeSetWidth(pipes), 3)
+	}
+}
+
+func TestRenderCommitGraphTimestampGutter(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	commits := []*models.Commit{
+		{Hash: "1", Parents: []string{"2", "3"}, UnixTimestamp: now.Unix() - 2*60*60},
+		{Hash: "2", Parents: []string{"4"}, UnixTimestamp: now.Unix() - 3*24*60*60},
+		{Hash: "3", Parents: []string{"4"}},
+		{Hash: "4", UnixTimestamp: now.Unix() - 400*24*60*60},
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+
+	lines := StripStyles(RenderCommitGraphWithOptions(commits, "", getStyle, Options{
+		TimestampGutterNow: now,
+		WIPParentHash:      "1",
+	}))
+
+	assert.Equal(t, []string{
+		"    ◌ ",
+		" 2h ⏣─╮ ",
+		" 3d ◯ │ ",
+		"    │ ◯ ",
+		" 1y ◯─╯ ",
+	}, lines)
+}
+
+func TestGetPipeSetsSentinels(t *testing.T) {
+	commits := []*models.Commit{
+		{Hash: "1", Parents: []string{"2", "3"}},
+		{Hash: "2", Parents: []string{"3"}},
+		{Hash: "3"},
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+	hashes := func(pipes []*Pipe) []string {
+		return lo.Map(pipes, func(pipe *Pipe, _ int) string { return pipe.fromHash + "->" + pipe.toHash })
+	}
+
+	pipeSets := GetPipeSets(commits, getStyle)
+	assert.Equal(t, []string{"START->1", "1->2", "1->3"}, hashes(pipeSets[0]))
+	assert.Equal(t, []string{"2->3", "1->3", "3->" + models.EmptyTreeCommitHash}, hashes(pipeSets[2]))
+
+	opts := Options{StartHash: "TOP", RootParentHash: "NONE"}
+	pipeSets = GetPipeSetsWithOptions(commits, getStyle, opts)
+	assert.Equal(t, []string{"TOP->1", "1->2", "1->3"}, hashes(pipeSets[0]))
+	assert.Equal(t, []string{"2->3", "1->3", "3->NONE"}, hashes(pipeSets[2]))
+	assert.Equal(t, RenderCommitGraph(commits, "", getStyle), RenderCommitGraphWithOptions(commits, "", getStyle, opts))
+
+	opts = Options{OmitStartPipe: true}
+	pipeSets = GetPipeSetsWithOptions(commits, getStyle, opts)
+	assert.Equal(t, []string{"1->2", "1->3"}, hashes(pipeSets[0]))
+	assert.Equal(t, RenderCommitGraph(commits, "", getStyle), RenderCommitGraphWithOptions(commits, "", getStyle, opts))
+}
+
+func TestFileHistory(t *testing.T) {
+	commits := []*models.Commit{
+		{Hash: "1", Parents: []string{"2"}},
+		{Hash: "2", Parents: []string{"3", "4"}},
+		{Hash: "3", Parents: []string{"5"}},
+		{Hash: "4", Parents: []string{"5"}},
+		{Hash: "5", Parents: []string{"6"}},
+	}
+	touchesFile := func(hash string) bool { return hash != "2" && hash != "4" }
+
+	history, skippingParents := FileHistory(commits, touchesFile)
+
+	assert.Equal(t, []string{"1", "3", "5"}, lo.Map(history, func(c *models.Commit, _ int) string { return c.Hash }))
+	assert.Equal(t, [][]string{{"3", "5"}, {"5"}, {"6"}}, lo.Map(history, func(c *models.Commit, _ int) []string { return c.Parents }))
+	assert.Equal(t, []string{"1"}, lo.Keys(skippingParents))
+	assert.ElementsMatch(t, []string{"3", "5"}, skippingParents["1"].ToSlice())
+	// the given commits are left alone
+	assert.Equal(t, []string{"2"}, commits[0].Parents)
+
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgGreen }
+	pipeSets := GetPipeSetsWithOptions(history, getStyle, Options{SkippingParents: skippingParents})
+	startStyles := lo.Map(pipeSets, func(pipes []*Pipe, _ int) []style.TextStyle {
+		return lo.FilterMap(pipes, func(pipe *Pipe, _ int) (style.TextStyle, bool) {
+			return pipe.style, pipe.kind == STARTS
+		})
+	})
+	assert.Equal(t, [][]style.TextStyle{
+		{dashedStyle, dashedStyle},
+		{style.FgGreen},
+		{style.FgGreen},
+	}, startStyles)
+
+	assert.Equal(t, []string{
+		"⏣─╮ ",
+		"◯ │ ",
+		"◯─╯ ",
+	}, StripStyles(RenderCommitGraphWithOptions(history, "", getStyle, Options{SkippingParents: skippingParents})))
+}
+
+func TestRenderCommitGraphStableLanes(t *testing.T) {
+	content, err := os.ReadFile("testdata/many_branches.commits")
+	assert.NoError(t, err)
+	commits := parseCommitsFixture(string(content))
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+
+	// by default, c's lane is pulled leftward into the gap left by b's lane
+	assert.Equal(t, []string{
+		"⏣─╮ ",
+		"⏣─│─╮ ",
+		"⏣─│─│─╮ ",
+		"│ ◯ │ │ ",
+		"│ │ ◯ │ ",
+		"│ ◯─╯ │ ",
+		"│ │   ◯ ",
+		"│ │   ◯ ",
+		"◯─╯ ╭─╯ ",
+		"◯───╯ ",
+	}, StripStyles(RenderCommitGraph(commits, "", getStyle)))
+
+	assert.Equal(t, []string{
+		"⏣─╮ ",
+		"⏣─│─╮ ",
+		"⏣─│─│─╮ ",
+		"│ ◯ │ │ ",
+		"│ │ ◯ │ ",
+		"│ ◯─╯ │ ",
+		"│ │   ◯ ",
+		"│ │   ◯ ",
+		"◯─╯   │ ",
+		"◯─────╯ ",
+	}, StripStyles(RenderCommitGraphWithOptions(commits, "", getStyle, Options{StableLanes: true})))
+}
+
+func TestRenderCommitGraphCoAuthors(t *testing.T) {
+	commits := []*models.Commit{
+		{Hash: "1", Parents: []string{"2", "3"}},
+		{Hash: "3", Parents: []string{"2"}},
+		{Hash: "2"},
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+	coAuthors := map[string][]string{"3": {"Jane Smith", "John Doe"}}
+
+	lines := StripStyles(RenderCommitGraphWithOptions(commits, "", getStyle, Options{CoAuthors: coAuthors}))
+
+	assert.Equal(t, []string{
+		"⏣─╮ ",
+		"│ ◯ +JS+JD ",
+		"◯─╯ ",
+	}, lines)
+}
+
+func TestGetPipeSetsDeterministic(t *testing.T) {
+	fixtures, err := filepath.Glob("testdata/*.commits")
+	assert.NoError(t, err)
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+
+	for _, fixture := range fixtures {
+		content, err := os.ReadFile(fixture)
+		assert.NoError(t, err)
+		commits := parseCommitsFixture(string(content))
+
+		assert.Equal(t,
+			RenderCommitGraph(commits, "", getStyle),
+			RenderCommitGraph(commits, "", getStyle),
+			fixture)
+
+		// no two pipes of a set are ordered arbitrarily
+		for _, pipes := range GetPipeSets(commits, getStyle) {
+			for i := 1; i < len(pipes); i++ {
+				a, b := pipes[i-1], pipes[i]
+				assert.NotEqual(t,
+					[]any{a.toPos, a.kind, a.fromPos, a.fromHash},
+					[]any{b.toPos, b.kind, b.fromPos, b.fromHash},
+					fixture)
+			}
+		}
+	}
+}
+
+func TestRenderCommitGraphGuides(t *testing.T) {
+	content, err := os.ReadFile("testdata/many_branches.commits")
+	assert.NoError(t, err)
+	commits := parseCommitsFixture(string(content))
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+
+	lines := RenderCommitGraphWithOptions(commits, "", getStyle, Options{GuideInterval: 2})
+
+	// guides never replace real pipes
+	assert.Equal(t, []string{
+		"⏣─╮ ┊   ",
+		"⏣─│─╮   ",
+		"⏣─│─│─╮ ",
+		"│ ◯ │ │ ",
+		"│ │ ◯ │ ",
+		"│ ◯─╯ │ ",
+		"│ │ ┊ ◯ ",
+		"│ │ ┊ ◯ ",
+		"◯─╯ ╭─╯ ",
+		"◯───╯   ",
+	}, StripStyles(lines))
+	assert.Contains(t, lines[0], guideStyle.Sprint("┊"))
+}
+
+func TestRenderCommitGraphDebugColumns(t *testing.T) {
+	commits := []*models.Commit{
+		commit("1", parents("2", "3")),
+		commit("3", parents("2")),
+		commit("2"),
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+
+	lines := StripStyles(RenderCommitGraphWithOptions(commits, "", getStyle, Options{DebugColumns: true}))
+
+	assert.Equal(t, []string{
+		"⏣─╮ [col=0] ",
+		"│ ◯ [col=1] ",
+		"◯─╯ [col=0] ",
+	}, lines)
+}
+
+func TestGetPipeSetsDuplicateParents(t *testing.T) {
+	commits := []*models.Commit{
+		commit("1", parents("2", "2")),
+		commit("2"),
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+
+	pipeSets := GetPipeSets(commits, getStyle)
+	pipesTo2 := lo.Filter(pipeSets[0], func(pipe *Pipe, _ int) bool { return pipe.toHash == "2" })
+	assert.Len(t, pipesTo2, 1)
+
+	assert.Equal(t, []string{
+		"◯ ",
+		"◯ ",
+	}, StripStyles(RenderCommitGraph(commits, "", getStyle)))
+}
+
+func TestRenderCommitGraphDetailed(t *testing.T) {
+	commits := []*models.Commit{
+		{Hash: "1", Parents: []string{"2", "3"}},
+		{Hash: "3", Parents: []string{"2"}},
+		{Hash: "2", Parents: []string{"4"}},
+		{Hash: "4"},
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+	opts := Options{DepthLimit: 3}
+
+	rows := RenderCommitGraphDetailed(commits, "", getStyle, opts)
+
+	assert.Equal(t, RenderCommitGraphWithOptions(commits, "", getStyle, opts), lo.Map(rows, func(row RowRender, _ int) string {
+		return row.Line
+	}))
+	assert.Equal(t, []RowRender{
+		{Hash: "1", IsMerge: true, Column: 0, PipeHashes: []string{"1", "2", "3"}},
+		{Hash: "3", IsMerge: false, Column: 1, PipeHashes: []string{"1", "2", "3"}},
+		{Hash: "2", IsMerge: false, Column: 0, PipeHashes: []string{"1", "2", "3", "4"}},
+		{Hash: "4", IsMerge: false, Column: -1, PipeHashes: []string{}},
+	}, lo.Map(rows, func(row RowRender, _ int) RowRender {
+		row.Line = ""
+		return row
+	}))
+}
+
+func TestRenderCommitGraphViewport(t *testing.T) {
+	commits := []*models.Commit{
+		commit("1", parents("2", "3")),
+		commit("3", parents("4")),
+		commit("2", parents("4")),
+		commit("4", parents("5")),
+		commit("5"),
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+
+	full := RenderCommitGraphWithOptions(commits, "3", getStyle, Options{})
+
+	// the top row continues the pipes from above rather than starting afresh
+	lines := RenderCommitGraphViewport(commits, 1, 2, "3", getStyle, Options{})
+	assert.Equal(t, full[1:3], lines)
+	assert.Equal(t, []string{"│ ◯ ", "◯ │ "}, StripStyles(lines))
+
+	// the viewport is clipped to the commits
+	assert.Equal(t, full[3:], RenderCommitGraphViewport(commits, 3, 10, "3", getStyle, Options{}))
+	assert.Empty(t, RenderCommitGraphViewport(commits, 5, 10, "3", getStyle, Options{}))
+	assert.Empty(t, RenderCommitGraphViewport(commits, 0, 0, "3", getStyle, Options{}))
+}
+
+func TestRenderGraphColumns(t *testing.T) {
+	commits := []*models.Commit{
+		commit("1", parents("2", "3")),
+		commit("3", parents("2")),
+		commit("2", parents("4")),
+		commit("4"),
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+	pipeSets := GetPipeSets(commits, getStyle)
+
+	lines := RenderGraphColumns(pipeSets, commits, "", Options{})
+
+	assert.Equal(t, []string{
+		"⏣─╮ ",
+		"│ ◯ ",
+		"◯─╯ ",
+		"◯   ",
+	}, StripStyles(lines))
+	assert.Empty(t, RenderGraphColumns(nil, nil, "", Options{}))
+}
+
+func TestValidateTopologicalOrder(t *testing.T) {
+	assert.NoError(t, ValidateTopologicalOrder(nil))
+	assert.NoError(t, ValidateTopologicalOrder([]*models.Commit{
+		commit("1", parents("2", "3")),
+		commit("3", parents("2")),
+		commit("2", parents("4")),
+	}))
+
+	// e.g. sorted by date, with 3's clock running ahead
+	assert.EqualError(t, ValidateTopologicalOrder([]*models.Commit{
+		commit("3", parents("2")),
+		commit("1", parents("2", "3")),
+		commit("2"),
+	}), "commit 3 comes before its child 1")
+}
+
+func TestEachPipeSet(t *testing.T) {
+	commits := []*models.Commit{
+		commit("1", parents("2", "3")),
+		commit("3", parents("2")),
+		commit("2", parents("4")),
+		commit("4"),
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+
+	rows := []int{}
+	pipeSets := [][]*Pipe{}
+	EachPipeSet(commits, getStyle, func(row int, pipes []*Pipe) bool {
+		rows = append(rows, row)
+		pipeSets = append(pipeSets, pipes)
+		return true
+	})
+	assert.Equal(t, []int{0, 1, 2, 3}, rows)
+	assert.Equal(t, GetPipeSets(commits, getStyle), pipeSets)
+
+	// stopping early
+	rows = []int{}
+	EachPipeSet(commits, getStyle, func(row int, pipes []*Pipe) bool {
+		rows = append(rows, row)
+		return row < 1
+	})
+	assert.Equal(t, []int{0, 1}, rows)
+}
+
+func TestVisibleTips(t *testing.T) {
+	// b forks off from 3, and 2's descendants are outside of the window
+	commits := []*models.Commit{
+		{Hash: "b2", Parents: []string{"b1"}},
+		{Hash: "2", Parents: []string{"3"}},
+		{Hash: "b1", Parents: []string{"3"}},
+		{Hash: "3", Parents: []string{"4"}},
+		{Hash: "4"},
+	}
+
+	assert.Equal(t, []string{"b2", "2"}, VisibleTips(commits))
+	assert.Equal(t, []string{}, VisibleTips(nil))
+}
+
+func TestHitTest(t *testing.T) {
+	commits := []*models.Commit{
+		{Hash: "1", Parents: []string{"2", "3"}},
+		{Hash: "2", Parents: []string{"4", "5"}},
+		{Hash: "3", Parents: []string{"4"}},
+		{Hash: "4", Parents: []string{"5"}},
+		{Hash: "5"},
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+	pipeSets := GetPipeSets(commits, getStyle)
+
+	// ⏣─╮
+	// ⏣─│─╮
+	// │ ◯ │
+	// ◯─╯ │
+	// ◯───╯
+	tests := []struct {
+		name         string
+		row, col     int
+		expectedHash string
+		expectedFrom string
+		expectedTo   string
+	}{
+		{name: "dot", row: 0, col: 0, expectedHash: "1"},
+		{name: "right half of dot", row: 2, col: 3, expectedHash: "3"},
+		{name: "corner", row: 0, col: 2, expectedFrom: "1", expectedTo: "3"},
+		{name: "crossing prefers the vertical pipe", row: 1, col: 2, expectedFrom: "1", expectedTo: "3"},
+		{name: "horizontal pipe", row: 4, col: 2, expectedFrom: "2", expectedTo: "5"},
+		{name: "second parent of merge", row: 1, col: 4, expectedFrom: "2", expectedTo: "5"},
+		{name: "terminating pipe", row: 3, col: 2, expectedFrom: "3", expectedTo: "4"},
+		{name: "blank cell", row: 2, col: 6},
+		{name: "row out of range", row: 5, col: 0},
+		{name: "negative column", row: 0, col: -1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hash, pipe := HitTest(pipeSets, commits, test.row, test.col)
+			assert.Equal(t, test.expectedHash, hash)
+			if test.expectedFrom == "" {
+				assert.Nil(t, pipe)
+				return
+			}
+			if assert.NotNil(t, pipe) {
+				assert.Equal(t, test.expectedFrom, pipe.FromHash())
+				assert.Equal(t, test.expectedTo, pipe.ToHash())
+			}
+		})
+	}
+}
+
+func TestRenderTagLabels(t *testing.T) {
+	annotatedTags := set.NewFromSlice([]string{"v1.0", "v2.0"})
+
+	assert.Equal(t, "", RenderTagLabels(nil, annotatedTags, Options{}))
+	assert.Equal(t, "nightly", RenderTagLabels([]string{"nightly"}, nil, Options{NonInteractive: true}))
+	// a commit carrying both kinds of tags
+	assert.Equal(t, "@v1.0 nightly @v2.0",
+		RenderTagLabels([]string{"v1.0", "nightly", "v2.0"}, annotatedTags, Options{NonInteractive: true}))
+
+	oldColorLevel := color.ForceSetColorLevel(terminfo.ColorLevelMillions)
+	defer color.ForceSetColorLevel(oldColorLevel)
+
+	assert.Equal(t,
+		annotatedTagStyle.Sprint("◇v1.0")+" "+lightweightTagStyle.Sprint("nightly"),
+		RenderTagLabels([]string{"v1.0", "nightly"}, annotatedTags, Options{}))
+}
+
+func TestRenderCommitGraphRebaseActions(t *testing.T) {
+	oldColorLevel := color.ForceSetColorLevel(terminfo.ColorLevelMillions)
+	defer color.ForceSetColorLevel(oldColorLevel)
+
+	commits := []*models.Commit{
+		commit("1", parents("2")),
+		commit("2", parents("3")),
+		commit("3", parents("4")),
+		commit("4", parents("5")),
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgGreen }
+	rebaseActions := map[string]todo.TodoCommand{
+		"1": todo.Drop,
+		"2": todo.Fixup,
+		"3": todo.Squash,
+		"4": todo.Pick,
+	}
+
+	lines := RenderCommitGraphWithOptions(commits, "", getStyle, Options{RebaseActions: rebaseActions})
+
+	assert.Equal(t, []string{
+		droppedStyle.Sprint("◯") + " ",
+		rebaseTodoGroupStyle.Sprint("◒") + " ",
+		rebaseTodoGroupStyle.Sprint("◒") + " ",
+		style.FgGreen.Sprint("◯") + " ",
+	}, lines)
+
+	// rendering is unchanged without rebase actions
+	assert.Equal(t,
+		RenderCommitGraphWithOptions(commits, "", getStyle, Options{}),
+		RenderCommitGraphWithOptions(commits, "", getStyle, Options{RebaseActions: map[string]todo.TodoCommand{}}))
+}
+
+func TestRenderCommitGraphDotGlyph(t *testing.T) {
+	commits := []*models.Commit{
+		commit("1", parents("2", "3")),
+		commit("3", parents("2")),
+		commit("2", parents("4")),
+		commit("4"),
+	}
+	dotGlyph := func(c *models.Commit) rune {
+		switch c.Hash {
+		case "1", "2":
+			return '◆'
+		case "3":
+			return '●'
+		}
+		// too wide, so the default dot is kept
+		return '中'
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+
+	lines := RenderCommitGraphWithOptions(commits, "", getStyle, Options{DotGlyph: dotGlyph})
+	// the merge keeps its own glyph
+	assert.Equal(t, []string{
+		"⏣─╮ ",
+		"│ ● ",
+		"◆─╯ ",
+		"◯ ",
+	}, StripStyles(lines))
+
+	lines = RenderCommitGraphWithOptions(commits, "", getStyle, Options{
+		DotGlyph:       func(c *models.Commit) rune { return lo.Ternary(c.Hash == "3", '*', '◆') },
+		NonInteractive: true,
+	})
+	assert.Equal(t, []string{
+		"M-. ",
+		"| * ",
+		"o-' ",
+		"o ",
+	}, lines)
+}
+
+func TestRenderCommitGraphPatchIDs(t *testing.T) {
+	oldColorLevel := color.ForceSetColorLevel(terminfo.ColorLevelMillions)
+	defer color.ForceSetColorLevel(oldColorLevel)
+
+	commits := []*models.Commit{
+		commit("1", parents("2")),
+		commit("2", parents("3")),
+		commit("3", parents("4")),
+		commit("4", parents("5")),
+		commit("5"),
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgGreen }
+	patchIDs := map[string]string{
+		"1": "p",
+		"2": "q",
+		"3": "r",
+		"4": "p",
+		"5": "q",
+	}
+
+	lines := RenderCommitGraphWithOptions(commits, "", getStyle, Options{PatchIDs: patchIDs})
+
+	assert.Equal(t, []string{
+		patchEquivalentPalette[0].Sprint("◯") + " ",
+		patchEquivalentPalette[1].Sprint("◯") + " ",
+		// no equivalent among the rendered commits
+		style.FgGreen.Sprint("◯") + " ",
+		patchEquivalentPalette[0].Sprint("◯") + " ",
+		patchEquivalentPalette[1].Sprint("◯") + " ",
+	}, lines)
+
+	// the pipes aren't affected
+	assert.Equal(t,
+		GetPipeSetsWithOptions(commits, getStyle, Options{}),
+		GetPipeSetsWithOptions(commits, getStyle, Options{PatchIDs: patchIDs}))
+}
+
+func TestNewPipe(t *testing.T) {
+	pipe := NewPipe(0, 1, "a", "b", STARTS, style.FgRed)
+
+	assert.Equal(t, &Pipe{fromPos: 0, toPos: 1, fromHash: "a", toHash: "b", kind: STARTS, style: style.FgRed}, pipe)
+
+	pipes := []*Pipe{
+		NewPipe(0, 0, "x", "a", TERMINATES, style.FgRed),
+		NewPipe(0, 0, "a", "c", STARTS, style.FgRed),
+		pipe,
+	}
+	assert.Equal(t, "⏣─╮ ", utils.Decolorise(RenderPipeSet(pipes, "", nil)))
+}
+
+func TestRenderCommitGraphMergeArrowheads(t *testing.T) {
+	commits := []*models.Commit{
+		commit("1", parents("2", "3")),
+		commit("4", parents("5", "2")),
+		commit("3", parents("2")),
+		commit("2", parents("5")),
+		commit("5"),
+	}
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+
+	lines := RenderCommitGraphWithOptions(commits, "", getStyle, Options{MergeArrowheads: true})
+	assert.Equal(t, []string{
+		"⏣◂╮ ",
+		"│ │ ⏣◂╮ ",
+		"│ ◯ │ │ ",
+		"◯─┴─│─╯ ",
+		"◯───╯ ",
+	}, StripStyles(lines))
+
+	lines = RenderCommitGraphWithOptions(commits, "", getStyle, Options{MergeArrowheads: true, RightAlign: true})
+	assert.Equal(t, []string{
+		"    ╭▸⏣ ",
+		"╭▸⏣ │ │ ",
+		"│ │ ◯ │ ",
+		"╰─│─┴─◯ ",
+		"  ╰───◯ ",
+	}, StripStyles(lines))
+
+	lines = RenderCommitGraphWithOptions(commits, "", getStyle, Options{MergeArrowheads: true, NonInteractive: true})
+	assert.Equal(t, "M<. ", lines[0])
+
+	// a second parent to the left of the merge
+	pipes := []*Pipe{
+		NewPipe(0, 0, "x", "y", CONTINUES, style.FgDefault),
+		NewPipe(1, 1, "z", "m", TERMINATES, style.FgDefault),
+		NewPipe(1, 1, "m", "a", STARTS, style.FgDefault),
+		NewPipe(1, 0, "m", "b", STARTS, style.FgDefault),
+	}
+	assert.Equal(t, "│▸⏣ ", utils.Decolorise(renderPipeSetWithOptions(pipes, "", rowContext{}, &Options{MergeArrowheads: true})))
 }
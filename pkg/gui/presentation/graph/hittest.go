@@ -0,0 +1,49 @@
+package graph
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/samber/lo"
+)
+
+// HitTest maps a position in the rendered graph back to what's drawn there,
+// e.g. to select a commit by clicking on it. row is the index of the rendered
+// row and col the index of the character within it; each column of the graph
+// is two characters wide (i.e. no ColumnGap). If the position is on the dot of
+// the row's commit, it returns the commit's hash. Otherwise it returns the
+// pipe occupying that cell, preferring one that passes through it vertically
+// over one that only crosses it. It returns neither for a blank cell or a
+// position outside of the graph.
+func HitTest(pipeSets [][]*Pipe, commits []*models.Commit, row, col int) (string, *Pipe) {
+	if row < 0 || row >= len(pipeSets) || row >= len(commits) || col < 0 {
+		return "", nil
+	}
+
+	pos := col / 2
+	pipes := lo.Filter(pipeSets[row], func(pipe *Pipe, _ int) bool {
+		return !isInvisible(pipe.style) && pipe.left() <= pos && pos <= pipe.right()
+	})
+
+	if lo.SomeBy(pipes, func(pipe *Pipe) bool { return pipe.kind == STARTS && pipe.fromPos == pos }) {
+		return commits[row].Hash, nil
+	}
+
+	if pipe, ok := lo.Find(pipes, func(pipe *Pipe) bool { return isVerticalAt(pipe, pos) }); ok {
+		return "", pipe
+	}
+	if len(pipes) > 0 {
+		return "", pipes[0]
+	}
+	return "", nil
+}
+
+// whether the pipe is drawn going up or down through the given column
+func isVerticalAt(pipe *Pipe, pos int) bool {
+	switch pipe.kind {
+	case STARTS:
+		return pipe.toPos == pos && !pipe.fromRoot
+	case TERMINATES:
+		return pipe.fromPos == pos
+	default:
+		return pipe.fromPos == pos || pipe.toPos == pos
+	}
+}
@@ -0,0 +1,49 @@
+package graph
+
+import (
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/mattn/go-runewidth"
+	"github.com/samber/lo"
+)
+
+// RenderLaneLabels renders a header row to go above the graph, naming the
+// branch of each active lane of the given pipe set (typically the first one
+// returned by GetPipeSets). A lane is named after the commit it comes from, as
+// given by branchNames (keyed by commit hash); lanes of commits that aren't in
+// branchNames are left blank. Names are truncated to fit their column, leaving
+// a space to separate them from the next one. Of the options, only ColumnGap
+// is taken into account.
+func RenderLaneLabels(pipes []*Pipe, branchNames map[string]string, opts Options) string {
+	activePipes := lo.Filter(pipes, func(pipe *Pipe, _ int) bool { return pipe.kind != TERMINATES })
+	if len(activePipes) == 0 {
+		return ""
+	}
+
+	labels := make([]string, lo.Max(lo.Map(activePipes, func(pipe *Pipe, _ int) int { return pipe.toPos }))+1)
+	labelled := map[string]bool{}
+	// a commit's own lane goes first so that e.g. a merge is named above its
+	// dot rather than above the lane of its second parent
+	ownLanesFirst := append(
+		lo.Filter(activePipes, func(pipe *Pipe, _ int) bool { return pipe.fromPos == pipe.toPos }),
+		lo.Filter(activePipes, func(pipe *Pipe, _ int) bool { return pipe.fromPos != pipe.toPos })...,
+	)
+	for _, pipe := range ownLanesFirst {
+		name, ok := branchNames[pipe.fromHash]
+		if !ok || labelled[pipe.fromHash] || labels[pipe.toPos] != "" {
+			continue
+		}
+		labels[pipe.toPos] = name
+		labelled[pipe.fromHash] = true
+	}
+
+	columnWidth := 2 * (1 + max(opts.ColumnGap, 0))
+
+	writer := &strings.Builder{}
+	for _, label := range labels {
+		label = runewidth.Truncate(label, columnWidth-1, "")
+		writer.WriteString(utils.WithPadding(label, columnWidth, utils.AlignLeft))
+	}
+	return writer.String()
+}
@@ -0,0 +1,639 @@
+package graph
+
+import (
+	"time"
+
+	"github.com/jesseduffield/generics/set"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/presentation/authors"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/mattn/go-runewidth"
+	"github.com/samber/lo"
+	"github.com/stefanhaller/git-todo-parser/todo"
+)
+
+// Options lets the caller tweak how the graph is laid out and rendered. The
+// zero value gives the default behaviour.
+type Options struct {
+	// Hashes of commits that are stash entries. A stash commit has the commit
+	// it was created on as its first parent, plus the index (and possibly the
+	// untracked files) commit as further parents. Those extra parents never
+	// appear in the commit list, so we only connect a stash to its base commit
+	// and render it with a distinct dot.
+	StashHashes *set.Set[string]
+
+	// The hash that the synthetic pipe leading into the first commit comes
+	// from; defaults to "START". Set OmitStartPipe to leave that pipe out
+	// altogether, e.g. for an embedder that doesn't want anything above the
+	// first commit to show up in the pipe sets.
+	StartHash     string
+	OmitStartPipe bool
+
+	// The hash that the pipe leading out of a root commit goes to, as a root
+	// has no actual parent; defaults to models.EmptyTreeCommitHash. There's no
+	// row for it, so the pipe always ends with the root's dot.
+	RootParentHash string
+
+	// Set this when the first commit isn't the tip of history (e.g. when
+	// rendering a window of a larger log). Instead of seeding the graph with a
+	// synthetic START pipe, we seed it with a pipe continuing from above.
+	ContinueFromAbove bool
+
+	// Maps the hash of a commit whose parents have been rewritten (via `git
+	// replace` or a shallow graft) to the parents we should actually draw pipes
+	// to. Such commits are rendered with a distinct dot.
+	RewrittenParents map[string][]string
+
+	// The number of filler cells to insert between adjacent columns of the
+	// graph, for more horizontal breathing room. Zero means no gap.
+	ColumnGap int
+
+	// The merge base of two branches (as given by `git merge-base`). Its dot is
+	// given a distinct style, as are the pipes of the lineages converging on it.
+	MergeBaseHash string
+
+	// Set this when the output is not going to an interactive terminal (e.g.
+	// when it's piped into a file). The graph is then rendered with plain ASCII
+	// characters and without any ANSI styling.
+	NonInteractive bool
+
+	// Replace the RGB colors of all styles (e.g. those returned by the getStyle
+	// callback) with the nearest of the 16 basic ANSI colors, so that the graph
+	// remains legible on terminals that don't support more.
+	BasicColors bool
+
+	// If set, the whole graph segment of the selected commit's row is given this
+	// background, like the selected line in a list view.
+	SelectedRowBackground *style.TextStyle
+
+	// If set, the graph segments of every other row (starting with the second
+	// one) are given this background, for zebra striping. It should be subtle,
+	// as the styles of the pipes and dots are kept on top of it.
+	StripeBackground *style.TextStyle
+
+	// If set, commits for which this returns false (e.g. reflog entries that
+	// are no longer on any branch) have their dot dimmed.
+	IsReachable func(hash string) bool
+
+	// Set this when the last commit being rendered isn't the last one in the
+	// history, i.e. more commits can still be loaded. Pipes continuing down
+	// from the last row are then drawn dashed to hint at that.
+	HasMore bool
+
+	// Set this when the last commit being rendered has parents that aren't
+	// rendered (e.g. when rendering a window of a larger log), to draw an arrow
+	// where its pipes to them leave the window, hinting that history continues
+	// below. Leave it unset when the last commit is a root of the history, so
+	// that its lane ends cleanly.
+	OffScreenParents bool
+
+	// Render the graph right-aligned, with the first column on the right and
+	// pipes growing leftward, e.g. for when the graph sits to the right of the
+	// commit subjects. All rows are padded to the same width.
+	RightAlign bool
+
+	// The hash of HEAD, to be set when the working tree has uncommitted
+	// changes. A virtual WIP commit is then drawn above the first commit, with
+	// HEAD as its parent. Leave this empty for a clean working tree.
+	WIPParentHash string
+
+	// Only show the dots of merge commits. The rows of all other commits are
+	// kept, but the pipes just pass through them.
+	OnlyMerges bool
+
+	// Hashes of the rows standing in for a collapsed topic branch (see
+	// TopicCollapser). They're rendered with a distinct dot.
+	CollapsedHashes *set.Set[string]
+
+	// Hashes of commits that don't change anything (e.g. created with `git
+	// commit --allow-empty`). They're rendered with a distinct dot so that they
+	// don't go unnoticed. Merge dots are unaffected.
+	EmptyHashes *set.Set[string]
+
+	// If positive, pipes are only computed for this many rows from the top.
+	// The remaining rows get empty pipe sets and are rendered blank, which saves
+	// us from laying out the full history when the user never scrolls that far.
+	DepthLimit int
+
+	// The number of changed lines (additions plus deletions) of commits, by
+	// hash. If set, the dots of commits that changed a lot are drawn heavier:
+	// one step heavier for each of the MagnitudeThresholds reached.
+	ChangeMagnitudes map[string]int
+
+	// Ascending numbers of changed lines; defaults to 100 and 1000. Thresholds
+	// beyond the number of available dot weights are ignored.
+	MagnitudeThresholds []int
+
+	// If set, the dots of commits in this set (e.g. those between two tags) are
+	// drawn filled and those of all other commits hollow, to make the range
+	// stand out. Merge dots are unaffected. This takes precedence over
+	// ChangeMagnitudes.
+	InRangeHashes *set.Set[string]
+
+	// Experimental: pack two rows into each line using braille characters, for
+	// a very dense overview. Rows that braille can't draw (e.g. merges) are
+	// rendered normally, so the lines no longer correspond to commits one to
+	// one. Only supported by RenderCommitGraphWithOptions, and ignored when
+	// NonInteractive is set.
+	Braille bool
+
+	// If set, column 0 is reserved for the first-parent lineage of this commit
+	// (typically HEAD), so that the current branch is always the leftmost lane
+	// and all other lanes are pushed to the right. Pipes are normally pulled
+	// leftward to fill in blank columns; with this set they're still pulled
+	// leftward, but never into column 0.
+	HeadHash string
+
+	// Experimental: lay out the graph with the selected commit's first-parent
+	// lineage in column 0, the way HeadHash does for HEAD, and draw the pipes
+	// along it highlighted. The lineage then runs straight down without any
+	// other lane crossing it, which makes it easy to trace, at the cost of
+	// pushing all other lanes to the right. This takes precedence over
+	// HeadHash. Only supported by RenderCommitGraphWithOptions.
+	RouteSelectedLineage bool
+
+	// If set, this is called when a row of the graph turns out to be wider than
+	// WidthThreshold columns, with the width of that row, e.g. so that the
+	// caller can switch to a more compact layout. It's called from
+	// GetPipeSetsWithOptions, at most once per call.
+	OnWidthExceeded func(width int)
+	WidthThreshold  int
+
+	// The hash of the previously selected commit, so that the user can find
+	// their way back to it. Its dot is given a subtle secondary highlight. If
+	// it's also the selected commit, the primary highlight wins.
+	PreviousHash string
+
+	// Hashes of the commits matching the current search. Their dots are drawn in
+	// a distinct style so that all matches stand out, while the selection stays
+	// on one of them.
+	SearchMatchHashes *set.Set[string]
+
+	// Draw the dots of merge commits, where the pipes to their parents come
+	// together, as a filled junction glyph, so that the places where branches
+	// actually merge stand out from the places where lanes merely cross.
+	JunctionGlyphs bool
+
+	// Draw an arrowhead where the pipes to a merge's second (and further)
+	// parents join its dot, so that what was merged in can be told apart from
+	// the mainline continuing down from the merge.
+	MergeArrowheads bool
+
+	// The glyphs to draw the corners where pipes turn with. Defaults to
+	// RoundedCorners.
+	CornerStyle CornerStyle
+
+	// If positive, a faint vertical guide line is drawn in every column whose
+	// index is a multiple of this, in the rows where that column is otherwise
+	// empty, to help the eye follow the lanes of a very wide graph. All rows
+	// are then padded to the same width.
+	GuideInterval int
+
+	// For debugging placement bugs: append the index of the column of the
+	// commit's dot to each row, as in "[col=2]".
+	DebugColumns bool
+
+	// Draw all pipes in a uniform neutral style, ignoring the getStyle callback
+	// except for hiding pipes with InvisibleStyle, and give the dots of commits
+	// and merges a color derived from the name of their author instead (the
+	// same one as in the author column).
+	AuthorColoredDots bool
+
+	// If set, returns the glyph to draw as the dot of a plain commit (i.e.
+	// not a merge, stash etc.) instead of CommitSymbol, e.g. to tell the
+	// commits of the main branch apart from those of feature branches.
+	// Returning 0, or a glyph that isn't exactly one cell wide, keeps the
+	// default dot. When NonInteractive is set, only ASCII glyphs are used.
+	DotGlyph func(commit *models.Commit) rune
+
+	// If set, the pipes leading down from a commit to its parents are drawn in
+	// the style this returns for the commit's style (e.g. a lighter shade of
+	// it), to tell them apart from the pipes leading up to its children.
+	DownwardStyle func(textStyle style.TextStyle) style.TextStyle
+
+	// The names of the co-authors of commits (see git_commands.ParseCoAuthors),
+	// by hash. Rows of commits with co-authors get a badge with their initials
+	// after the graph, so it doesn't affect the alignment of the pipes. Not
+	// supported together with RightAlign.
+	CoAuthors map[string][]string
+
+	// If set, commits authored before this time have their dots and the pipes
+	// to their parents drawn faded, so that recent work stands out. Commits
+	// without a timestamp count as recent.
+	FadeBefore time.Time
+
+	// If set, each row starts with a gutter showing how long before this time
+	// (typically now) the commit was authored, e.g. " 3d ". The gutter has a
+	// fixed width so that the graph stays aligned, and is left blank for
+	// commits without a timestamp (including the WIP commit). Not supported
+	// together with Braille.
+	TimestampGutterNow time.Time
+
+	// If positive, the graph is at most this many columns wide. Lanes that
+	// don't fit are spilled over into the last column, which is drawn as a
+	// spillover glyph followed by the number of extra lanes in it, rather than
+	// widening the graph indefinitely. Lanes keep their place while spilled
+	// over, so they reappear where they were once there's room again.
+	MaxLanes int
+
+	// Keep each pipe in the column it started in, rather than pulling pipes
+	// leftward to fill in the blank columns left behind by lanes that ended.
+	// Lanes then don't drift sideways, at the cost of leaving gaps.
+	StableLanes bool
+
+	// Parents that commits are connected to by skipping over commits that
+	// aren't shown, keyed by the hash of the child (see FileHistory). The
+	// pipes to them are drawn dashed.
+	SkippingParents map[string]*set.Set[string]
+
+	// The actions planned for commits during an interactive rebase, by hash.
+	// The dots of commits that will be dropped are drawn dimmed, and those of
+	// commits that will be squashed or fixed up into the commit below them
+	// are drawn as FoldedSymbol. Commits without an action are drawn as usual.
+	RebaseActions map[string]todo.TodoCommand
+
+	// The patch ids of commits (see `git patch-id`), by hash. Commits sharing
+	// their patch id with another of the rendered commits (e.g. because one
+	// was cherry-picked from the other) get their dots drawn in a matching
+	// style, so that duplicated work is easy to spot even though there's no
+	// pipe between them. Each such group of commits gets its own style, in
+	// order of appearance.
+	PatchIDs map[string]string
+
+	// computed from MergeBaseHash: the merge base and all of its descendants
+	mergeBaseLineage *set.Set[string]
+	// computed from HeadHash: the commits on HEAD's first-parent lineage
+	headLineage *set.Set[string]
+	// set from RouteSelectedLineage: draw the pipes along headLineage
+	// highlighted
+	highlightHeadLineage bool
+	// computed from PatchIDs: the dot styles of the commits that have a
+	// patch-equivalent among the rendered commits
+	patchEquivalentStyles map[string]style.TextStyle
+	// computed when AuthorColoredDots is set: the dot styles of the rendered
+	// commits' authors, by author name, so that the rendering goroutines
+	// don't have to go through the (unsynchronised) author style cache
+	authorStyles map[string]style.TextStyle
+	// computed from CoAuthors: the badges of the rendered commits, by hash,
+	// for the same reason
+	coAuthorsBadges map[string]string
+}
+
+type CornerStyle int
+
+const (
+	// ╭ ╮ ╰ ╯
+	RoundedCorners CornerStyle = iota
+	// ┌ ┐ └ ┘, for fonts whose rounded corners don't line up with the rest of
+	// the box-drawing characters
+	SharpCorners
+)
+
+var (
+	mergeBaseStyle        = style.FgMagenta.SetBold()
+	mergeBaseLineageStyle = style.FgMagenta
+	unreachableStyle      = style.FgBlackLighter
+	searchMatchStyle      = style.FgYellow.SetBold()
+	previousHashStyle     = style.FgLightWhite
+	fadedStyle            = style.FgBlackLighter
+	droppedStyle          = style.FgBlackLighter
+	// handed out in turn to the groups of patch-equivalent commits
+	patchEquivalentPalette = []style.TextStyle{
+		style.FgCyan.SetBold(),
+		style.FgMagenta.SetBold(),
+		style.FgYellow.SetBold(),
+		style.FgGreen.SetBold(),
+		style.FgBlue.SetBold(),
+		style.FgRed.SetBold(),
+	}
+	neutralPipeStyle = style.FgDefault
+)
+
+func (self *Options) startHash() string {
+	if self.StartHash == "" {
+		return "START"
+	}
+	return self.StartHash
+}
+
+func (self *Options) rootParentHash() string {
+	if self.RootParentHash == "" {
+		return models.EmptyTreeCommitHash
+	}
+	return self.RootParentHash
+}
+
+func (self *Options) isBeyondDepthLimit(index int) bool {
+	return self.DepthLimit > 0 && index >= self.DepthLimit
+}
+
+// WIPCommitHash is the hash of the virtual commit standing in for the working
+// tree's uncommitted changes (see Options.WIPParentHash).
+const WIPCommitHash = "WIP"
+
+func isWIP(commit *models.Commit) bool {
+	return commit.Hash == WIPCommitHash
+}
+
+// prepends the virtual WIP commit to the commits, if we want one
+func (self *Options) withWIPCommit(commits []*models.Commit) []*models.Commit {
+	if self.WIPParentHash == "" {
+		return commits
+	}
+
+	wipCommit := &models.Commit{Hash: WIPCommitHash, Parents: []string{self.WIPParentHash}}
+	return append([]*models.Commit{wipCommit}, commits...)
+}
+
+func (self *Options) isStash(commit *models.Commit) bool {
+	return self.StashHashes != nil && self.StashHashes.Includes(commit.Hash)
+}
+
+func (self *Options) isEmpty(commit *models.Commit) bool {
+	return self.EmptyHashes != nil && self.EmptyHashes.Includes(commit.Hash)
+}
+
+func (self *Options) isCollapsed(commit *models.Commit) bool {
+	return self.CollapsedHashes != nil && self.CollapsedHashes.Includes(commit.Hash)
+}
+
+// whether we draw the pipes passing through the commit rather than its dot
+func (self *Options) isHidden(commit *models.Commit) bool {
+	return self.OnlyMerges && len(self.parentsOf(commit)) < 2
+}
+
+var defaultMagnitudeThresholds = []int{100, 1000}
+
+// the index into commitSymbolsByWeight of the dot to draw for a plain commit
+func (self *Options) dotWeight(commit *models.Commit) int {
+	if self.InRangeHashes != nil {
+		if self.InRangeHashes.Includes(commit.Hash) {
+			return len(commitSymbolsByWeight) - 1
+		}
+		return 0
+	}
+
+	magnitude, ok := self.ChangeMagnitudes[commit.Hash]
+	if !ok {
+		return 0
+	}
+
+	thresholds := self.MagnitudeThresholds
+	if thresholds == nil {
+		thresholds = defaultMagnitudeThresholds
+	}
+
+	weight := 0
+	for _, threshold := range thresholds {
+		if magnitude >= threshold && weight < len(commitSymbolsByWeight)-1 {
+			weight++
+		}
+	}
+	return weight
+}
+
+// the glyph to draw as the dot of a plain commit instead of the default one,
+// or 0 if there is none
+func (self *Options) dotGlyph(commit *models.Commit) rune {
+	if self.DotGlyph == nil {
+		return 0
+	}
+
+	glyph := self.DotGlyph(commit)
+	// anything wider or narrower would throw off the alignment of the lanes
+	if runewidth.RuneWidth(glyph) != 1 {
+		return 0
+	}
+	return glyph
+}
+
+func (self *Options) downwardStyle(textStyle style.TextStyle) style.TextStyle {
+	if self.DownwardStyle == nil {
+		return textStyle
+	}
+	return self.DownwardStyle(textStyle)
+}
+
+func (self *Options) isFaded(commit *models.Commit) bool {
+	return !self.FadeBefore.IsZero() && commit.UnixTimestamp != 0 &&
+		time.Unix(commit.UnixTimestamp, 0).Before(self.FadeBefore)
+}
+
+func (self *Options) isDropped(commit *models.Commit) bool {
+	return self.RebaseActions[commit.Hash] == todo.Drop
+}
+
+// whether the commit will be folded into the one below it by the rebase
+func (self *Options) isFolded(commit *models.Commit) bool {
+	action := self.RebaseActions[commit.Hash]
+	return action == todo.Squash || action == todo.Fixup
+}
+
+func (self *Options) isGrafted(commit *models.Commit) bool {
+	_, ok := self.RewrittenParents[commit.Hash]
+	return ok
+}
+
+// the parents that we actually want to draw pipes to
+func (self *Options) parentsOf(commit *models.Commit) []string {
+	parents := commit.Parents
+	if rewrittenParents, ok := self.RewrittenParents[commit.Hash]; ok {
+		parents = rewrittenParents
+	}
+
+	if self.isStash(commit) && len(parents) > 1 {
+		return parents[:1]
+	}
+
+	// a malformed commit may list the same parent twice, which would give us
+	// overlapping pipes
+	if len(parents) > 1 {
+		return lo.Uniq(parents)
+	}
+
+	return parents
+}
+
+// commits are ordered children-first, so by walking them bottom-up we see each
+// commit's parents before the commit itself
+func (self *Options) computeMergeBaseLineage(commits []*models.Commit) {
+	if self.MergeBaseHash == "" {
+		return
+	}
+
+	self.mergeBaseLineage = set.NewFromSlice([]string{self.MergeBaseHash})
+	for i := len(commits) - 1; i >= 0; i-- {
+		commit := commits[i]
+		for _, parent := range self.parentsOf(commit) {
+			if self.mergeBaseLineage.Includes(parent) {
+				self.mergeBaseLineage.Add(commit.Hash)
+				break
+			}
+		}
+	}
+}
+
+func (self *Options) computeHeadLineage(commits []*models.Commit) {
+	if self.HeadHash == "" {
+		return
+	}
+
+	commitsByHash := lo.SliceToMap(commits, func(commit *models.Commit) (string, *models.Commit) {
+		return commit.Hash, commit
+	})
+	self.headLineage = set.New[string]()
+	head, ok := lo.Find(commits, func(commit *models.Commit) bool {
+		return utils.EqualHashes(commit.Hash, self.HeadHash)
+	})
+	for ok {
+		self.headLineage.Add(head.Hash)
+		parents := self.parentsOf(head)
+		if len(parents) == 0 {
+			break
+		}
+		head, ok = commitsByHash[parents[0]]
+	}
+}
+
+func (self *Options) computePatchEquivalentStyles(commits []*models.Commit) {
+	if len(self.PatchIDs) == 0 {
+		return
+	}
+
+	hashesByPatchID := map[string][]string{}
+	patchIDs := []string{}
+	for _, commit := range commits {
+		patchID, ok := self.PatchIDs[commit.Hash]
+		if !ok || patchID == "" {
+			continue
+		}
+		if _, seen := hashesByPatchID[patchID]; !seen {
+			patchIDs = append(patchIDs, patchID)
+		}
+		hashesByPatchID[patchID] = append(hashesByPatchID[patchID], commit.Hash)
+	}
+
+	self.patchEquivalentStyles = map[string]style.TextStyle{}
+	group := 0
+	for _, patchID := range patchIDs {
+		hashes := hashesByPatchID[patchID]
+		if len(hashes) < 2 {
+			continue
+		}
+		for _, hash := range hashes {
+			self.patchEquivalentStyles[hash] = patchEquivalentPalette[group%len(patchEquivalentPalette)]
+		}
+		group++
+	}
+}
+
+func (self *Options) computeAuthorStyles(commits []*models.Commit) {
+	if !self.AuthorColoredDots {
+		return
+	}
+
+	self.authorStyles = map[string]style.TextStyle{}
+	for _, commit := range commits {
+		if _, ok := self.authorStyles[commit.AuthorName]; !ok {
+			self.authorStyles[commit.AuthorName] = authors.AuthorStyle(commit.AuthorName)
+		}
+	}
+}
+
+func (self *Options) computeCoAuthorsBadges(commits []*models.Commit) {
+	if len(self.CoAuthors) == 0 {
+		return
+	}
+
+	self.coAuthorsBadges = map[string]string{}
+	for _, commit := range commits {
+		if badge := authors.CoAuthorsBadge(self.CoAuthors[commit.Hash]); badge != "" {
+			self.coAuthorsBadges[commit.Hash] = badge
+		}
+	}
+}
+
+func (self *Options) reservesFirstColumn() bool {
+	return self.headLineage != nil
+}
+
+func (self *Options) isOnHeadLineage(hash string) bool {
+	return self.headLineage != nil && self.headLineage.Includes(hash)
+}
+
+// the first column that a pipe between the given commits may be placed in
+func (self *Options) firstColumnFor(fromHash string, toHash string) int {
+	if self.reservesFirstColumn() && !(self.isOnHeadLineage(fromHash) && self.isOnHeadLineage(toHash)) {
+		return 1
+	}
+	return 0
+}
+
+func (self *Options) pipeStyle(commit *models.Commit, parent string, getStyle func(c *models.Commit) style.TextStyle) style.TextStyle {
+	if isWIP(commit) {
+		return dashedStyle
+	}
+
+	if skipping, ok := self.SkippingParents[commit.Hash]; ok && skipping.Includes(parent) {
+		return dashedStyle
+	}
+
+	// a root commit's only pipe is the one to rootParentHash
+	parents := self.parentsOf(commit)
+	if self.highlightHeadLineage && self.isOnHeadLineage(commit.Hash) && (len(parents) == 0 || parent == parents[0]) {
+		return highlightStyle
+	}
+
+	if self.mergeBaseLineage != nil && self.mergeBaseLineage.Includes(commit.Hash) &&
+		commit.Hash != self.MergeBaseHash && self.mergeBaseLineage.Includes(parent) {
+		return mergeBaseLineageStyle
+	}
+
+	if self.isFaded(commit) {
+		return fadedStyle
+	}
+
+	return getStyle(commit)
+}
+
+// returns the style to use for the commit's dot, if it needs a special one
+func (self *Options) dotStyle(commit *models.Commit) (style.TextStyle, bool) {
+	if isWIP(commit) {
+		return dashedStyle, true
+	}
+
+	if self.isDropped(commit) {
+		return droppedStyle, true
+	}
+
+	if self.isFolded(commit) {
+		return rebaseTodoGroupStyle, true
+	}
+
+	if self.PreviousHash != "" && utils.EqualHashes(commit.Hash, self.PreviousHash) {
+		return previousHashStyle, true
+	}
+
+	if self.SearchMatchHashes != nil && self.SearchMatchHashes.Includes(commit.Hash) {
+		return searchMatchStyle, true
+	}
+
+	if utils.EqualHashes(commit.Hash, self.MergeBaseHash) {
+		return mergeBaseStyle, true
+	}
+
+	if textStyle, ok := self.patchEquivalentStyles[commit.Hash]; ok {
+		return textStyle, true
+	}
+
+	if self.isFaded(commit) {
+		return fadedStyle, true
+	}
+
+	if self.IsReachable != nil && !self.IsReachable(commit.Hash) {
+		return unreachableStyle, true
+	}
+
+	return style.TextStyle{}, false
+}
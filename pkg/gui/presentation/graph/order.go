@@ -0,0 +1,28 @@
+package graph
+
+import (
+	"fmt"
+
+	"github.com/jesseduffield/generics/set"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+)
+
+// ValidateTopologicalOrder returns an error if a commit comes before one of its
+// children in the given commits. The graph can only be laid out for commits in
+// topological order (children before parents, as e.g. `git log --topo-order`
+// or `--date-order` gives them): a pipe to a parent that has already been
+// passed never ends, so the graph gets tangled. Callers that sort commits
+// differently (e.g. by author date) should check this before rendering.
+func ValidateTopologicalOrder(commits []*models.Commit) error {
+	seen := set.New[string]()
+	for _, commit := range commits {
+		for _, parent := range commit.Parents {
+			if seen.Includes(parent) {
+				return fmt.Errorf("commit %s comes before its child %s", parent, commit.Hash)
+			}
+		}
+		seen.Add(commit.Hash)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package graph
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/stefanhaller/git-todo-parser/todo"
+)
+
+var rebaseTodoGroupStyle = style.FgMagenta
+
+// RenderRebaseTodoGraph renders a single-lane graph for the todos of an
+// interactive rebase, as they're shown in the commits view (i.e. the todo that
+// is applied last comes first). A squash or fixup is folded into the todo
+// below it, so each run of consecutive squash/fixup todos gets a bracket
+// joining it with the todo it's folded into.
+func RenderRebaseTodoGraph(todos []*models.Commit) []string {
+	brackets := make([]string, len(todos))
+	for i := range brackets {
+		brackets[i] = " "
+	}
+
+	for i := 0; i < len(todos); i++ {
+		if !isFoldedTodo(todos[i]) {
+			continue
+		}
+
+		end := i
+		for end+1 < len(todos) && isFoldedTodo(todos[end+1]) {
+			end++
+		}
+		// include the todo that the run is folded into, if there is one
+		if end+1 < len(todos) {
+			end++
+		}
+
+		if end > i {
+			brackets[i] = "╮"
+			for j := i + 1; j < end; j++ {
+				brackets[j] = "│"
+			}
+			brackets[end] = "╯"
+		}
+		i = end
+	}
+
+	lines := make([]string, len(todos))
+	for i, bracket := range brackets {
+		line := string(CommitSymbol) + " "
+		if bracket != " " {
+			line += rebaseTodoGroupStyle.Sprint(bracket)
+		} else {
+			line += bracket
+		}
+		lines[i] = line + " "
+	}
+
+	return lines
+}
+
+func isFoldedTodo(commit *models.Commit) bool {
+	return commit.Action == todo.Squash || commit.Action == todo.Fixup
+}
@@ -0,0 +1,40 @@
+package graph
+
+import (
+	"strings"
+
+	"github.com/jesseduffield/generics/set"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/samber/lo"
+)
+
+// drawn in front of the label of an annotated tag (see RenderTagLabels)
+const AnnotatedTagSymbol = '◇'
+
+var (
+	annotatedTagStyle   = style.FgYellow.SetBold()
+	lightweightTagStyle = style.FgYellow
+)
+
+// RenderTagLabels renders the labels of the tags decorating a commit (e.g.
+// commit.Tags), to go next to its row of the graph. Tags whose names are in
+// annotatedTags are drawn bold and prefixed with AnnotatedTagSymbol, so that
+// they stand out from lightweight tags; a commit carrying both kinds gets each
+// of its tags labelled according to its own kind, in the given order. Of the
+// options, only NonInteractive and BasicColors are taken into account.
+func RenderTagLabels(tags []string, annotatedTags *set.Set[string], opts Options) string {
+	labels := lo.Map(tags, func(tag string, _ int) string {
+		isAnnotated := annotatedTags != nil && annotatedTags.Includes(tag)
+		if opts.NonInteractive {
+			return lo.Ternary(isAnnotated, asciiReplacements[string(AnnotatedTagSymbol)]+tag, tag)
+		}
+
+		textStyle := lo.Ternary(isAnnotated, annotatedTagStyle, lightweightTagStyle)
+		if opts.BasicColors {
+			textStyle = textStyle.ToBasicColors()
+		}
+		return cachedSprint(textStyle, lo.Ternary(isAnnotated, string(AnnotatedTagSymbol)+tag, tag))
+	})
+
+	return strings.Join(labels, " ")
+}
@@ -0,0 +1,21 @@
+package graph
+
+import (
+	"github.com/jesseduffield/generics/set"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/samber/lo"
+)
+
+// VisibleTips returns the hashes of the commits that are branch tips within
+// the given commits, i.e. that no other of the commits lists as a parent. They
+// come in the same order as the commits.
+func VisibleTips(commits []*models.Commit) []string {
+	parents := set.New[string]()
+	for _, commit := range commits {
+		parents.Add(commit.Parents...)
+	}
+
+	return lo.FilterMap(commits, func(commit *models.Commit, _ int) (string, bool) {
+		return commit.Hash, !parents.Includes(commit.Hash)
+	})
+}
@@ -305,6 +305,13 @@ func (self *HandlerCreator) finalHandler(customCommand config.CustomCommand, ses
 			self.c.Alert(title, output)
 		}
 
+		if customCommand.CopyOutputToClipboard != nil && *customCommand.CopyOutputToClipboard {
+			if err := self.c.OS().CopyToClipboard(strings.TrimRight(output, "\r\n")); err != nil {
+				return err
+			}
+			self.c.Toast(self.c.Tr.CustomCommandOutputCopiedToClipboard)
+		}
+
 		return nil
 	})
 }
@@ -37,3 +37,46 @@ func (c Color) ToRGB(isBg bool) Color {
 
 	return NewRGBColor(c.basic.RGB())
 }
+
+// the foreground variants of the 16 basic ANSI colors; the background variant
+// of each is 10 higher
+var basicFgColors = []color.Color{
+	color.FgBlack, color.FgRed, color.FgGreen, color.FgYellow,
+	color.FgBlue, color.FgMagenta, color.FgCyan, color.FgWhite,
+	color.FgDarkGray, color.FgLightRed, color.FgLightGreen, color.FgLightYellow,
+	color.FgLightBlue, color.FgLightMagenta, color.FgLightCyan, color.FgLightWhite,
+}
+
+// NearestBasicColor returns the foreground color among the 16 basic ANSI
+// colors that is closest to the given RGB color.
+func NearestBasicColor(rgb color.RGBColor) color.Color {
+	nearest := basicFgColors[0]
+	nearestDistance := -1
+	for _, candidate := range basicFgColors {
+		candidateRGB := candidate.RGB()
+		distance := 0
+		for i := 0; i < 3; i++ {
+			diff := int(rgb[i]) - int(candidateRGB[i])
+			distance += diff * diff
+		}
+		if nearestDistance == -1 || distance < nearestDistance {
+			nearest = candidate
+			nearestDistance = distance
+		}
+	}
+	return nearest
+}
+
+// ToBasic returns the color itself if it's a basic color, or else the nearest
+// basic color
+func (c Color) ToBasic(isBg bool) Color {
+	if !c.IsRGB() {
+		return c
+	}
+
+	nearest := NearestBasicColor(*c.rgb)
+	if isBg {
+		nearest += 10
+	}
+	return NewBasicColor(nearest)
+}
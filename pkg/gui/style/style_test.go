@@ -172,6 +172,33 @@ func TestMerge(t *testing.T) {
 	}
 }
 
+func TestNearestBasicColor(t *testing.T) {
+	scenarios := []struct {
+		rgb      color.RGBColor
+		expected color.Color
+	}{
+		{color.Rgb(250, 10, 10), color.FgRed},
+		{color.Rgb(0x5f, 0xaf, 0xff), color.FgLightBlue},
+		{color.Rgb(20, 20, 20), color.FgBlack},
+		{color.Rgb(255, 255, 255), color.FgLightWhite},
+	}
+
+	for _, s := range scenarios {
+		assert.Equal(t, s.expected, NearestBasicColor(s.rgb))
+	}
+}
+
+func TestToBasicColors(t *testing.T) {
+	oldColorLevel := color.ForceSetColorLevel(terminfo.ColorLevelMillions)
+	defer color.ForceSetColorLevel(oldColorLevel)
+
+	rgbStyle := New().SetFg(NewRGBColor(color.Rgb(250, 10, 10))).SetBg(NewRGBColor(color.Rgb(20, 20, 20))).SetBold()
+	assert.Equal(t, FgRed.SetBg(NewBasicColor(color.BgBlack)).SetBold().Sprint("foo"), rgbStyle.ToBasicColors().Sprint("foo"))
+
+	// basic colors are kept as they are
+	assert.Equal(t, FgMagenta.Sprint("foo"), FgMagenta.ToBasicColors().Sprint("foo"))
+}
+
 func TestTemplateFuncMapAddColors(t *testing.T) {
 	type scenario struct {
 		name   string
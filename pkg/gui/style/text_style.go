@@ -93,6 +93,23 @@ func (b TextStyle) SetFg(color Color) TextStyle {
 	return b
 }
 
+// ToBasicColors returns the style with any RGB colors replaced by the nearest
+// of the 16 basic ANSI colors, for terminals that don't support more.
+func (b TextStyle) ToBasicColors() TextStyle {
+	if b.fg != nil {
+		fg := b.fg.ToBasic(false)
+		b.fg = &fg
+	}
+
+	if b.bg != nil {
+		bg := b.bg.ToBasic(true)
+		b.bg = &bg
+	}
+
+	b.Style = b.deriveStyle()
+	return b
+}
+
 func (b TextStyle) MergeStyle(other TextStyle) TextStyle {
 	b.decoration = b.decoration.Merge(other.decoration)
 
@@ -79,12 +79,25 @@ type TranslationSet struct {
 	CopyFilePath                          string
 	CopyFileDiffTooltip                   string
 	CopySelectedDiff                      string
+	CopySelectedDirectoryDiff             string
 	CopyAllFilesDiff                      string
+	CopyConflictedContent                 string
+	CopyMarkdownDiff                      string
+	CopyRenameAwareDiff                   string
+	CopyWordDiff                          string
+	CopyDiffIgnoringWhitespace            string
+	CopyChangedFilePaths                  string
 	NoContentToCopyError                  string
+	FileHasNoMergeConflicts               string
 	FileNameCopiedToast                   string
 	FilePathCopiedToast                   string
 	FileDiffCopiedToast                   string
+	DirectoryPathCopiedToast              string
+	DirectoryDiffCopiedToast              string
 	AllFilesDiffCopiedToast               string
+	ConflictedContentCopiedToast          string
+	MarkdownDiffCopiedToast               string
+	ChangedFilePathsCopiedToast           string
 	FilterStagedFiles                     string
 	FilterUnstagedFiles                   string
 	FilterTrackedFiles                    string
@@ -215,6 +228,8 @@ type TranslationSet struct {
 	StashChanges                          string
 	RenameStash                           string
 	RenameStashPrompt                     string
+	CopyStashDiff                         string
+	CopyStashDiffTooltip                  string
 	OpenConfig                            string
 	EditConfig                            string
 	ForcePush                             string
@@ -615,6 +630,11 @@ type TranslationSet struct {
 	ShowingGitDiff                        string
 	ShowingDiffForRange                   string
 	CommitDiff                            string
+	CommitDiffForPath                     string
+	CommitDiffPathFilterTitle             string
+	CommitRangeDiff                       string
+	CommitCombinedRangeDiff               string
+	CommitHashes                          string
 	CopyCommitHashToClipboard             string
 	CommitHash                            string
 	CommitURL                             string
@@ -625,14 +645,30 @@ type TranslationSet struct {
 	CommitMessageBody                     string
 	CommitSubject                         string
 	CommitAuthor                          string
+	CommitTreeFileList                    string
+	CommitReflogEntry                     string
+	ReflogEntryNotAvailableWhileFiltering string
+	CommitDiffVsWorkingTree               string
+	CommitFormatPatch                     string
+	CommitDiffStat                        string
 	CommitTags                            string
 	CopyCommitAttributeToClipboard        string
 	CopyCommitAttributeToClipboardTooltip string
 	CopyBranchNameToClipboard             string
+	CopySelectedLine                      string
+	CopySelectedLineTooltip               string
+	CopyViewContents                      string
+	CopyViewContentsTooltip               string
 	CopyTagToClipboard                    string
 	CopyPathToClipboard                   string
 	CommitPrefixPatternError              string
 	CopySelectedTextToClipboard           string
+	CopySelectedHunkAsPatch               string
+	CopySelectedHunkAsPatchTooltip        string
+	CopyBlameInfo                         string
+	CopyBlameInfoTooltip                  string
+	HunkPatchCopiedToClipboard            string
+	BlameInfoCopiedToClipboard            string
 	NoFilesStagedTitle                    string
 	NoFilesStagedPrompt                   string
 	BranchNotFoundTitle                   string
@@ -685,6 +721,17 @@ type TranslationSet struct {
 	PushingTagStatus                         string
 	PullRequestURLCopiedToClipboard          string
 	CommitDiffCopiedToClipboard              string
+	RangeDiffRequiresRangeSelection          string
+	CombinedRangeDiffRequiresRangeSelection  string
+	CommitHashesRequireRangeSelection        string
+	RangeDiffCopiedToClipboard               string
+	CombinedRangeDiffCopiedToClipboard       string
+	CommitHashesCopiedToClipboard            string
+	TreeFileListCopiedToClipboard            string
+	ReflogEntryCopiedToClipboard             string
+	DiffVsWorkingTreeCopiedToClipboard       string
+	FormatPatchCopiedToClipboard             string
+	DiffStatCopiedToClipboard                string
 	CommitURLCopiedToClipboard               string
 	CommitMessageCopiedToClipboard           string
 	CommitMessageBodyCopiedToClipboard       string
@@ -695,6 +742,10 @@ type TranslationSet struct {
 	CommitHasNoMessageBody                   string
 	PatchCopiedToClipboard                   string
 	CopiedToClipboard                        string
+	SelectedLineCopiedToClipboard            string
+	StashDiffCopiedToClipboard               string
+	CustomCommandOutputCopiedToClipboard     string
+	ViewContentsCopiedToClipboard            string
 	ErrCannotEditDirectory                   string
 	ErrStageDirWithInlineMergeConflicts      string
 	ErrRepositoryMovedOrDeleted              string
@@ -923,6 +974,15 @@ type Actions struct {
 	CopyCommitMessageBodyToClipboard  string
 	CopyCommitSubjectToClipboard      string
 	CopyCommitDiffToClipboard         string
+	CopyCommitDiffForPathToClipboard  string
+	CopyRangeDiffToClipboard          string
+	CopyCombinedRangeDiffToClipboard  string
+	CopyCommitHashesToClipboard       string
+	CopyTreeFileListToClipboard       string
+	CopyReflogEntryToClipboard        string
+	CopyDiffVsWorkingTreeToClipboard  string
+	CopyFormatPatchToClipboard        string
+	CopyDiffStatToClipboard           string
 	CopyCommitHashToClipboard         string
 	CopyCommitURLToClipboard          string
 	CopyCommitAuthorToClipboard       string
@@ -959,6 +1019,9 @@ type Actions struct {
 	GitFlowStart                      string
 	CopyToClipboard                   string
 	CopySelectedTextToClipboard       string
+	CopySelectedHunkAsPatch           string
+	CopyBlameInfoToClipboard          string
+	CopyConflictedContentToClipboard  string
 	RemovePatchFromCommit             string
 	MovePatchToSelectedCommit         string
 	MovePatchIntoIndex                string
@@ -971,6 +1034,7 @@ type Actions struct {
 	ApplyPatch                        string
 	Stash                             string
 	RenameStash                       string
+	CopyStashDiffToClipboard          string
 	RemoveSubmodule                   string
 	ResetSubmodule                    string
 	AddSubmodule                      string
@@ -1119,12 +1183,25 @@ func EnglishTranslationSet() *TranslationSet {
 		CopyFilePath:                         "Path",
 		CopyFileDiffTooltip:                  "If there are staged items, this command considers only them. Otherwise, it considers all the unstaged ones.",
 		CopySelectedDiff:                     "Diff of selected file",
+		CopySelectedDirectoryDiff:            "Diff of selected directory",
 		CopyAllFilesDiff:                     "Diff of all files",
+		CopyConflictedContent:                "Conflicted content",
+		CopyMarkdownDiff:                     "Markdown diff of selected file",
+		CopyRenameAwareDiff:                  "Diff (detect renames)",
+		CopyWordDiff:                         "Word diff",
+		CopyDiffIgnoringWhitespace:           "Diff (ignore whitespace)",
+		CopyChangedFilePaths:                 "Changed file paths",
 		NoContentToCopyError:                 "Nothing to copy",
+		FileHasNoMergeConflicts:              "File has no merge conflicts",
 		FileNameCopiedToast:                  "File name copied to clipboard",
 		FilePathCopiedToast:                  "File path copied to clipboard",
 		FileDiffCopiedToast:                  "File diff copied to clipboard",
+		DirectoryPathCopiedToast:             "Directory path copied to clipboard",
+		DirectoryDiffCopiedToast:             "Directory diff copied to clipboard",
 		AllFilesDiffCopiedToast:              "All files diff copied to clipboard",
+		ConflictedContentCopiedToast:         "Conflicted content copied to clipboard",
+		MarkdownDiffCopiedToast:              "Diff copied to clipboard as markdown",
+		ChangedFilePathsCopiedToast:          "Changed file paths copied to clipboard",
 		FilterStagedFiles:                    "Show only staged files",
 		FilterUnstagedFiles:                  "Show only unstaged files",
 		FilterTrackedFiles:                   "Show only tracked files",
@@ -1249,6 +1326,8 @@ func EnglishTranslationSet() *TranslationSet {
 		StashChanges:                         "Stash changes",
 		RenameStash:                          "Rename stash",
 		RenameStashPrompt:                    "Rename stash: {{.stashName}}",
+		CopyStashDiff:                        "Copy stash diff",
+		CopyStashDiffTooltip:                 "Copy the diff of the selected stash entry to the clipboard.",
 		OpenConfig:                           "Open config file",
 		EditConfig:                           "Edit config file",
 		ForcePush:                            "Force push",
@@ -1657,6 +1736,11 @@ func EnglishTranslationSet() *TranslationSet {
 		ShowingGitDiff:                           "Showing output for:",
 		ShowingDiffForRange:                      "Showing diff for range",
 		CommitDiff:                               "Commit diff",
+		CommitDiffForPath:                        "Diff (path filter)",
+		CommitDiffPathFilterTitle:                "Path to limit the diff to:",
+		CommitRangeDiff:                          "Range diff (A...B)",
+		CommitCombinedRangeDiff:                  "Combined range diff",
+		CommitHashes:                             "Commit hashes",
 		CopyCommitHashToClipboard:                "Copy commit hash to clipboard",
 		CommitHash:                               "Commit hash",
 		CommitURL:                                "Commit URL",
@@ -1667,13 +1751,29 @@ func EnglishTranslationSet() *TranslationSet {
 		CommitMessageBody:                        "Commit message body",
 		CommitSubject:                            "Commit subject",
 		CommitAuthor:                             "Commit author",
+		CommitTreeFileList:                       "Tree file list",
+		CommitReflogEntry:                        "Reflog entry",
+		ReflogEntryNotAvailableWhileFiltering:    "The reflog selector is not known while filtering",
+		CommitDiffVsWorkingTree:                  "Diff vs working tree",
+		CommitFormatPatch:                        "Format-patch",
+		CommitDiffStat:                           "Diffstat",
 		CommitTags:                               "Commit tags",
 		CopyCommitAttributeToClipboard:           "Copy commit attribute to clipboard",
 		CopyCommitAttributeToClipboardTooltip:    "Copy commit attribute to clipboard (e.g. hash, URL, diff, message, author).",
 		CopyBranchNameToClipboard:                "Copy branch name to clipboard",
+		CopySelectedLine:                         "Copy selected line",
+		CopySelectedLineTooltip:                  "Copy the text of the selected line of the focused view to the clipboard.",
+		CopyViewContents:                         "Copy view contents",
+		CopyViewContentsTooltip:                  "Copy all lines of the focused view to the clipboard, e.g. for pasting into a bug report.",
 		CopyTagToClipboard:                       "Copy tag to clipboard",
 		CopyPathToClipboard:                      "Copy path to clipboard",
 		CopySelectedTextToClipboard:              "Copy selected text to clipboard",
+		CopySelectedHunkAsPatch:                  "Copy hunk as patch to clipboard",
+		CopySelectedHunkAsPatchTooltip:           "Copy the hunk containing the selected line, along with the file header, as a patch that can be applied with `git apply`.",
+		CopyBlameInfo:                            "Copy blame info to clipboard",
+		CopyBlameInfoTooltip:                     "Copy the abbreviated hash, author and date of the commit that last changed the selected line, followed by the line itself, e.g. \"1a2b3c4d (Jane Doe, 2024-01-31) some code\". Lines that haven't been committed yet are attributed to \"Not Committed Yet\".",
+		HunkPatchCopiedToClipboard:               "Hunk patch copied to clipboard",
+		BlameInfoCopiedToClipboard:               "Blame info copied to clipboard",
 		CommitPrefixPatternError:                 "Error in commitPrefix pattern",
 		NoFilesStagedTitle:                       "No files staged",
 		NoFilesStagedPrompt:                      "You have not staged any files. Commit all files?",
@@ -1726,6 +1826,17 @@ func EnglishTranslationSet() *TranslationSet {
 		PushingTagStatus:                         "Pushing tag",
 		PullRequestURLCopiedToClipboard:          "Pull request URL copied to clipboard",
 		CommitDiffCopiedToClipboard:              "Commit diff copied to clipboard",
+		RangeDiffRequiresRangeSelection:          "Select a range of commits to copy the diff between its first and last commit",
+		CombinedRangeDiffRequiresRangeSelection:  "Select a range of commits to copy their combined diff",
+		CommitHashesRequireRangeSelection:        "Select a range of commits to copy their hashes",
+		RangeDiffCopiedToClipboard:               "Range diff copied to clipboard",
+		CombinedRangeDiffCopiedToClipboard:       "Combined range diff copied to clipboard",
+		CommitHashesCopiedToClipboard:            "Commit hashes copied to clipboard",
+		TreeFileListCopiedToClipboard:            "Tree file list copied to clipboard",
+		ReflogEntryCopiedToClipboard:             "Reflog entry copied to clipboard",
+		DiffVsWorkingTreeCopiedToClipboard:       "Diff vs working tree copied to clipboard",
+		FormatPatchCopiedToClipboard:             "Format-patch copied to clipboard",
+		DiffStatCopiedToClipboard:                "Diffstat copied to clipboard",
 		CommitURLCopiedToClipboard:               "Commit URL copied to clipboard",
 		CommitMessageCopiedToClipboard:           "Commit message copied to clipboard",
 		CommitMessageBodyCopiedToClipboard:       "Commit message body copied to clipboard",
@@ -1736,6 +1847,10 @@ func EnglishTranslationSet() *TranslationSet {
 		CommitHasNoMessageBody:                   "Commit has no message body",
 		PatchCopiedToClipboard:                   "Patch copied to clipboard",
 		CopiedToClipboard:                        "copied to clipboard",
+		SelectedLineCopiedToClipboard:            "Selected line copied to clipboard",
+		StashDiffCopiedToClipboard:               "Stash diff copied to clipboard",
+		CustomCommandOutputCopiedToClipboard:     "Command output copied to clipboard",
+		ViewContentsCopiedToClipboard:            "View contents copied to clipboard",
 		ErrCannotEditDirectory:                   "Cannot edit directories: you can only edit individual files",
 		ErrStageDirWithInlineMergeConflicts:      "Cannot stage/unstage directory containing files with inline merge conflicts. Please fix up the merge conflicts first",
 		ErrRepositoryMovedOrDeleted:              "Cannot find repo. It might have been moved or deleted ¯\\_(ツ)_/¯",
@@ -1923,6 +2038,15 @@ func EnglishTranslationSet() *TranslationSet {
 			CopyCommitSubjectToClipboard:     "Copy commit subject to clipboard",
 			CopyCommitTagsToClipboard:        "Copy commit tags to clipboard",
 			CopyCommitDiffToClipboard:        "Copy commit diff to clipboard",
+			CopyCommitDiffForPathToClipboard: "Copy commit diff for path to clipboard",
+			CopyRangeDiffToClipboard:         "Copy range diff to clipboard",
+			CopyCombinedRangeDiffToClipboard: "Copy combined range diff to clipboard",
+			CopyCommitHashesToClipboard:      "Copy commit hashes to clipboard",
+			CopyTreeFileListToClipboard:      "Copy tree file list to clipboard",
+			CopyReflogEntryToClipboard:       "Copy reflog entry to clipboard",
+			CopyDiffVsWorkingTreeToClipboard: "Copy diff vs working tree to clipboard",
+			CopyFormatPatchToClipboard:       "Copy format-patch to clipboard",
+			CopyDiffStatToClipboard:          "Copy diffstat to clipboard",
 			CopyCommitHashToClipboard:        "Copy full commit hash to clipboard",
 			CopyCommitURLToClipboard:         "Copy commit URL to clipboard",
 			CopyCommitAuthorToClipboard:      "Copy commit author to clipboard",
@@ -1936,78 +2060,82 @@ func EnglishTranslationSet() *TranslationSet {
 			DiscardAllChangesInDirectory:      "Discard all changes in directory",
 			DiscardUnstagedChangesInDirectory: "Discard unstaged changes in directory",
 
-			DiscardAllChangesInFile:         "Discard all changes in selected file(s)",
-			DiscardAllUnstagedChangesInFile: "Discard all unstaged changes selected file(s)",
-			StageFile:                       "Stage file",
-			StageResolvedFiles:              "Stage files whose merge conflicts were resolved",
-			UnstageFile:                     "Unstage file",
-			UnstageAllFiles:                 "Unstage all files",
-			StageAllFiles:                   "Stage all files",
-			NotEnoughContextToStage:         "Staging or unstaging changes is not possible with a diff context size of 0. Increase the context using '%s'.",
-			NotEnoughContextToDiscard:       "Discarding changes is not possible with a diff context size of 0. Increase the context using '%s'.",
-			IgnoreExcludeFile:               "Ignore or exclude file",
-			IgnoreFileErr:                   "Cannot ignore .gitignore",
-			ExcludeFile:                     "Exclude file",
-			ExcludeGitIgnoreErr:             "Cannot exclude .gitignore",
-			Commit:                          "Commit",
-			EditFile:                        "Edit file",
-			Push:                            "Push",
-			Pull:                            "Pull",
-			OpenFile:                        "Open file",
-			StashAllChanges:                 "Stash all changes",
-			StashAllChangesKeepIndex:        "Stash all changes and keep index",
-			StashStagedChanges:              "Stash staged changes",
-			StashUnstagedChanges:            "Stash unstaged changes",
-			StashIncludeUntrackedChanges:    "Stash all changes including untracked files",
-			GitFlowFinish:                   "git flow finish",
-			GitFlowStart:                    "git flow start",
-			CopyToClipboard:                 "Copy to clipboard",
-			CopySelectedTextToClipboard:     "Copy selected text to clipboard",
-			RemovePatchFromCommit:           "Remove patch from commit",
-			MovePatchToSelectedCommit:       "Move patch to selected commit",
-			MovePatchIntoIndex:              "Move patch into index",
-			MovePatchIntoNewCommit:          "Move patch into new commit",
-			DeleteRemoteBranch:              "Delete remote branch",
-			SetBranchUpstream:               "Set branch upstream",
-			AddRemote:                       "Add remote",
-			RemoveRemote:                    "Remove remote",
-			UpdateRemote:                    "Update remote",
-			ApplyPatch:                      "Apply patch",
-			Stash:                           "Stash",
-			RenameStash:                     "Rename stash",
-			RemoveSubmodule:                 "Remove submodule",
-			ResetSubmodule:                  "Reset submodule",
-			AddSubmodule:                    "Add submodule",
-			UpdateSubmoduleUrl:              "Update submodule URL",
-			InitialiseSubmodule:             "Initialise submodule",
-			BulkInitialiseSubmodules:        "Bulk initialise submodules",
-			BulkUpdateSubmodules:            "Bulk update submodules",
-			BulkDeinitialiseSubmodules:      "Bulk deinitialise submodules",
-			UpdateSubmodule:                 "Update submodule",
-			DeleteLocalTag:                  "Delete local tag",
-			DeleteRemoteTag:                 "Delete remote tag",
-			PushTag:                         "Push tag",
-			NukeWorkingTree:                 "Nuke working tree",
-			DiscardUnstagedFileChanges:      "Discard unstaged file changes",
-			RemoveUntrackedFiles:            "Remove untracked files",
-			RemoveStagedFiles:               "Remove staged files",
-			SoftReset:                       "Soft reset",
-			MixedReset:                      "Mixed reset",
-			HardReset:                       "Hard reset",
-			FastForwardBranch:               "Fast forward branch",
-			Undo:                            "Undo",
-			Redo:                            "Redo",
-			CopyPullRequestURL:              "Copy pull request URL",
-			OpenDiffTool:                    "Open diff tool",
-			OpenMergeTool:                   "Open merge tool",
-			OpenCommitInBrowser:             "Open commit in browser",
-			OpenPullRequest:                 "Open pull request in browser",
-			StartBisect:                     "Start bisect",
-			ResetBisect:                     "Reset bisect",
-			BisectSkip:                      "Bisect skip",
-			BisectMark:                      "Bisect mark",
-			RemoveWorktree:                  "Remove worktree",
-			AddWorktree:                     "Add worktree",
+			DiscardAllChangesInFile:          "Discard all changes in selected file(s)",
+			DiscardAllUnstagedChangesInFile:  "Discard all unstaged changes selected file(s)",
+			StageFile:                        "Stage file",
+			StageResolvedFiles:               "Stage files whose merge conflicts were resolved",
+			UnstageFile:                      "Unstage file",
+			UnstageAllFiles:                  "Unstage all files",
+			StageAllFiles:                    "Stage all files",
+			NotEnoughContextToStage:          "Staging or unstaging changes is not possible with a diff context size of 0. Increase the context using '%s'.",
+			NotEnoughContextToDiscard:        "Discarding changes is not possible with a diff context size of 0. Increase the context using '%s'.",
+			IgnoreExcludeFile:                "Ignore or exclude file",
+			IgnoreFileErr:                    "Cannot ignore .gitignore",
+			ExcludeFile:                      "Exclude file",
+			ExcludeGitIgnoreErr:              "Cannot exclude .gitignore",
+			Commit:                           "Commit",
+			EditFile:                         "Edit file",
+			Push:                             "Push",
+			Pull:                             "Pull",
+			OpenFile:                         "Open file",
+			StashAllChanges:                  "Stash all changes",
+			StashAllChangesKeepIndex:         "Stash all changes and keep index",
+			StashStagedChanges:               "Stash staged changes",
+			StashUnstagedChanges:             "Stash unstaged changes",
+			StashIncludeUntrackedChanges:     "Stash all changes including untracked files",
+			GitFlowFinish:                    "git flow finish",
+			GitFlowStart:                     "git flow start",
+			CopyToClipboard:                  "Copy to clipboard",
+			CopySelectedTextToClipboard:      "Copy selected text to clipboard",
+			CopySelectedHunkAsPatch:          "Copy hunk as patch to clipboard",
+			CopyBlameInfoToClipboard:         "Copy blame info to clipboard",
+			CopyConflictedContentToClipboard: "Copy conflicted content to clipboard",
+			RemovePatchFromCommit:            "Remove patch from commit",
+			MovePatchToSelectedCommit:        "Move patch to selected commit",
+			MovePatchIntoIndex:               "Move patch into index",
+			MovePatchIntoNewCommit:           "Move patch into new commit",
+			DeleteRemoteBranch:               "Delete remote branch",
+			SetBranchUpstream:                "Set branch upstream",
+			AddRemote:                        "Add remote",
+			RemoveRemote:                     "Remove remote",
+			UpdateRemote:                     "Update remote",
+			ApplyPatch:                       "Apply patch",
+			Stash:                            "Stash",
+			RenameStash:                      "Rename stash",
+			CopyStashDiffToClipboard:         "Copy stash diff to clipboard",
+			RemoveSubmodule:                  "Remove submodule",
+			ResetSubmodule:                   "Reset submodule",
+			AddSubmodule:                     "Add submodule",
+			UpdateSubmoduleUrl:               "Update submodule URL",
+			InitialiseSubmodule:              "Initialise submodule",
+			BulkInitialiseSubmodules:         "Bulk initialise submodules",
+			BulkUpdateSubmodules:             "Bulk update submodules",
+			BulkDeinitialiseSubmodules:       "Bulk deinitialise submodules",
+			UpdateSubmodule:                  "Update submodule",
+			DeleteLocalTag:                   "Delete local tag",
+			DeleteRemoteTag:                  "Delete remote tag",
+			PushTag:                          "Push tag",
+			NukeWorkingTree:                  "Nuke working tree",
+			DiscardUnstagedFileChanges:       "Discard unstaged file changes",
+			RemoveUntrackedFiles:             "Remove untracked files",
+			RemoveStagedFiles:                "Remove staged files",
+			SoftReset:                        "Soft reset",
+			MixedReset:                       "Mixed reset",
+			HardReset:                        "Hard reset",
+			FastForwardBranch:                "Fast forward branch",
+			Undo:                             "Undo",
+			Redo:                             "Redo",
+			CopyPullRequestURL:               "Copy pull request URL",
+			OpenDiffTool:                     "Open diff tool",
+			OpenMergeTool:                    "Open merge tool",
+			OpenCommitInBrowser:              "Open commit in browser",
+			OpenPullRequest:                  "Open pull request in browser",
+			StartBisect:                      "Start bisect",
+			ResetBisect:                      "Reset bisect",
+			BisectSkip:                       "Bisect skip",
+			BisectMark:                       "Bisect mark",
+			RemoveWorktree:                   "Remove worktree",
+			AddWorktree:                      "Add worktree",
 		},
 		Bisect: Bisect{
 			Mark:                        "Mark current commit (%s) as %s",
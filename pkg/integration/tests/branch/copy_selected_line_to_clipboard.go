@@ -0,0 +1,39 @@
+package branch
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+// We're emulating the clipboard by writing to a file called clipboard
+
+var CopySelectedLineToClipboard = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Copy the text of the selected line in the branches view to the clipboard",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(config *config.AppConfig) {
+		config.GetUserConfig().OS.CopyToClipboardCmd = "printf '%s' {{text}} > clipboard"
+	},
+
+	SetupRepo: func(shell *Shell) {
+		shell.EmptyCommit("one")
+		shell.NewBranch("branch-a")
+		shell.NewBranch("branch-b")
+	},
+
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Branches().
+			Focus().
+			Lines(
+				Contains("branch-b").IsSelected(),
+				Contains("branch-a"),
+				Contains("master"),
+			).
+			NavigateToLine(Contains("branch-a")).
+			Press(keys.Universal.CopySelectedLine)
+
+		t.ExpectToast(Equals("Selected line copied to clipboard"))
+
+		t.FileSystem().FileContent("clipboard", Contains("branch-a").DoesNotContain("branch-b"))
+	},
+})
@@ -0,0 +1,56 @@
+package commit
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+// We're emulating the clipboard by writing to a file called clipboard
+
+var CopyCombinedRangeDiffToClipboard = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Copy the changes of a range of selected commits as a single diff",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(config *config.AppConfig) {
+		config.GetUserConfig().OS.CopyToClipboardCmd = "printf '%s' {{text}} > clipboard"
+	},
+
+	SetupRepo: func(shell *Shell) {
+		shell.CreateFileAndAdd("file1", "1st line\n")
+		shell.Commit("one")
+		shell.CreateFileAndAdd("file2", "content\n")
+		shell.UpdateFileAndAdd("file1", "changed line\n")
+		shell.Commit("two")
+	},
+
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Commits().
+			Focus().
+			Lines(
+				Contains("two").IsSelected(),
+				Contains("one"),
+			).
+			Press(keys.Universal.RangeSelectDown).
+			Lines(
+				Contains("two").IsSelected(),
+				Contains("one").IsSelected(),
+			).
+			Press(keys.Commits.CopyCommitAttributeToClipboard)
+
+		t.ExpectPopup().Menu().
+			Title(Equals("Copy to clipboard")).
+			Select(Contains("Combined range diff")).
+			Confirm()
+
+		t.ExpectToast(Equals("Combined range diff copied to clipboard"))
+
+		// only the net changes: file1 is new as of the first commit, so the
+		// line it had in between doesn't show up
+		t.FileSystem().FileContent("clipboard",
+			Contains("diff --git a/file1 b/file1\nnew file mode").
+				Contains("+changed line").
+				Contains("diff --git a/file2 b/file2\nnew file mode").
+				Contains("+content").
+				DoesNotContain("1st line"))
+	},
+})
@@ -0,0 +1,50 @@
+package commit
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+// We're emulating the clipboard by writing to a file called clipboard
+
+var CopyCommitHashesToClipboard = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Copy the full hashes of a range of selected commits, one per line",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(config *config.AppConfig) {
+		config.GetUserConfig().OS.CopyToClipboardCmd = "printf '%s' {{text}} > clipboard"
+	},
+
+	SetupRepo: func(shell *Shell) {
+		shell.EmptyCommit("one")
+		shell.EmptyCommit("two")
+		shell.EmptyCommit("three")
+	},
+
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Commits().
+			Focus().
+			Lines(
+				Contains("three").IsSelected(),
+				Contains("two"),
+				Contains("one"),
+			).
+			Press(keys.Universal.RangeSelectDown).
+			Lines(
+				Contains("three").IsSelected(),
+				Contains("two").IsSelected(),
+				Contains("one"),
+			).
+			Press(keys.Commits.CopyCommitAttributeToClipboard)
+
+		t.ExpectPopup().Menu().
+			Title(Equals("Copy to clipboard")).
+			Select(Contains("Commit hashes")).
+			Confirm()
+
+		t.ExpectToast(Equals("Commit hashes copied to clipboard"))
+
+		t.FileSystem().FileContent("clipboard",
+			Equals(t.Git().GetCommitHash("HEAD")+"\n"+t.Git().GetCommitHash("HEAD^")))
+	},
+})
@@ -0,0 +1,51 @@
+package commit
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+// We're emulating the clipboard by writing to a file called clipboard
+
+var CopyDiffForPathToClipboard = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Copy the diff of a commit, limited to a path, to the clipboard",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(config *config.AppConfig) {
+		config.GetUserConfig().OS.CopyToClipboardCmd = "printf '%s' {{text}} > clipboard"
+	},
+
+	SetupRepo: func(shell *Shell) {
+		shell.CreateDir("dir")
+		shell.CreateDir("other")
+		shell.CreateFileAndAdd("dir/file1", "dir content\n")
+		shell.CreateFileAndAdd("other/file2", "other content\n")
+		shell.Commit("one")
+	},
+
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Commits().
+			Focus().
+			Lines(
+				Contains("one").IsSelected(),
+			).
+			Press(keys.Commits.CopyCommitAttributeToClipboard)
+
+		t.ExpectPopup().Menu().
+			Title(Equals("Copy to clipboard")).
+			Select(Contains("Diff (path filter)")).
+			Confirm()
+
+		t.ExpectPopup().Prompt().
+			Title(Equals("Path to limit the diff to:")).
+			Type("dir/").
+			Confirm()
+
+		t.ExpectToast(Equals("Commit diff copied to clipboard"))
+
+		t.FileSystem().FileContent("clipboard",
+			Contains("diff --git a/dir/file1 b/dir/file1").
+				Contains("+dir content").
+				DoesNotContain("other"))
+	},
+})
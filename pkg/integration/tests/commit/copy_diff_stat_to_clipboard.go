@@ -0,0 +1,48 @@
+package commit
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+// We're emulating the clipboard by writing to a file called clipboard
+
+var CopyDiffStatToClipboard = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Copy the diffstat of a commit to the clipboard",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(config *config.AppConfig) {
+		config.GetUserConfig().OS.CopyToClipboardCmd = "printf '%s' {{text}} > clipboard"
+	},
+
+	SetupRepo: func(shell *Shell) {
+		shell.CreateFileAndAdd("file1", "1st line\n")
+		shell.Commit("one")
+		shell.UpdateFileAndAdd("file1", "1st line\n2nd line\n")
+		shell.CreateFileAndAdd("file2", "content\n")
+		shell.Commit("two")
+	},
+
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Commits().
+			Focus().
+			Lines(
+				Contains("two").IsSelected(),
+				Contains("one"),
+			).
+			Press(keys.Commits.CopyCommitAttributeToClipboard)
+
+		t.ExpectPopup().Menu().
+			Title(Equals("Copy to clipboard")).
+			Select(Contains("Diffstat")).
+			Confirm()
+
+		t.ExpectToast(Equals("Diffstat copied to clipboard"))
+
+		t.FileSystem().FileContent("clipboard",
+			Contains("file1 | 1 +").
+				Contains("file2 | 1 +").
+				Contains("2 files changed, 2 insertions(+)").
+				DoesNotContain("Author:"))
+	},
+})
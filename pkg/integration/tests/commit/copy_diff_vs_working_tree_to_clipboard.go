@@ -0,0 +1,49 @@
+package commit
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+// We're emulating the clipboard by writing to a file called clipboard
+
+var CopyDiffVsWorkingTreeToClipboard = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Copy the diff between a commit and the working tree, including uncommitted changes",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(config *config.AppConfig) {
+		config.GetUserConfig().OS.CopyToClipboardCmd = "printf '%s' {{text}} > clipboard"
+	},
+
+	SetupRepo: func(shell *Shell) {
+		shell.CreateFileAndAdd("file", "1st line\n")
+		shell.Commit("one")
+		shell.UpdateFileAndAdd("file", "1st line\n2nd line\n")
+		shell.Commit("two")
+		shell.UpdateFile("file", "1st line\n2nd line\n3rd line\n")
+	},
+
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Commits().
+			Focus().
+			Lines(
+				Contains("two").IsSelected(),
+				Contains("one"),
+			).
+			NavigateToLine(Contains("one")).
+			Press(keys.Commits.CopyCommitAttributeToClipboard)
+
+		t.ExpectPopup().Menu().
+			Title(Equals("Copy to clipboard")).
+			Select(Contains("Diff vs working tree")).
+			Confirm()
+
+		t.ExpectToast(Equals("Diff vs working tree copied to clipboard"))
+
+		// both the change of commit "two" and the uncommitted change
+		t.FileSystem().FileContent("clipboard",
+			Contains("diff --git a/file b/file").
+				Contains("+2nd line").
+				Contains("+3rd line"))
+	},
+})
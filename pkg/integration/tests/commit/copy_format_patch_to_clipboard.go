@@ -0,0 +1,45 @@
+package commit
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+// We're emulating the clipboard by writing to a file called clipboard
+
+var CopyFormatPatchToClipboard = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Copy a commit as a patch in the format of git format-patch",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(config *config.AppConfig) {
+		config.GetUserConfig().OS.CopyToClipboardCmd = "printf '%s' {{text}} > clipboard"
+	},
+
+	SetupRepo: func(shell *Shell) {
+		shell.CreateFileAndAdd("file", "1st line\n")
+		shell.Commit("one")
+		shell.UpdateFileAndAdd("file", "1st line\n2nd line\n")
+		shell.Commit("two")
+	},
+
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Commits().
+			Focus().
+			Lines(
+				Contains("two").IsSelected(),
+				Contains("one"),
+			).
+			Press(keys.Commits.CopyCommitAttributeToClipboard)
+
+		t.ExpectPopup().Menu().
+			Title(Equals("Copy to clipboard")).
+			Select(Contains("Format-patch")).
+			Confirm()
+
+		t.ExpectToast(Equals("Format-patch copied to clipboard"))
+
+		t.FileSystem().FileContent("clipboard",
+			Contains("Subject: [PATCH] two").
+				Contains("+2nd line"))
+	},
+})
@@ -0,0 +1,55 @@
+package commit
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+// We're emulating the clipboard by writing to a file called clipboard
+
+var CopyRangeDiffToClipboard = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Copy the three-dot diff between the first and last of a range of selected commits",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(config *config.AppConfig) {
+		config.GetUserConfig().OS.CopyToClipboardCmd = "printf '%s' {{text}} > clipboard"
+	},
+
+	SetupRepo: func(shell *Shell) {
+		shell.CreateFileAndAdd("file", "1st line\n")
+		shell.Commit("one")
+		shell.UpdateFileAndAdd("file", "1st line\n2nd line\n")
+		shell.Commit("two")
+		shell.UpdateFileAndAdd("file", "1st line\n2nd line\n3rd line\n")
+		shell.Commit("three")
+	},
+
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Commits().
+			Focus().
+			Lines(
+				Contains("three").IsSelected(),
+				Contains("two"),
+				Contains("one"),
+			).
+			Press(keys.Universal.RangeSelectDown).
+			Lines(
+				Contains("three").IsSelected(),
+				Contains("two").IsSelected(),
+				Contains("one"),
+			).
+			Press(keys.Commits.CopyCommitAttributeToClipboard)
+
+		t.ExpectPopup().Menu().
+			Title(Equals("Copy to clipboard")).
+			Select(Contains("Range diff (A...B)")).
+			Confirm()
+
+		t.ExpectToast(Equals("Range diff copied to clipboard"))
+
+		t.FileSystem().FileContent("clipboard",
+			Contains("diff --git a/file b/file").
+				Contains("+3rd line").
+				DoesNotContain("+2nd line"))
+	},
+})
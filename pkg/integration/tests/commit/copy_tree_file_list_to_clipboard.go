@@ -0,0 +1,46 @@
+package commit
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+// We're emulating the clipboard by writing to a file called clipboard
+
+var CopyTreeFileListToClipboard = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Copy the list of all files tracked at a commit, not just the changed ones",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(config *config.AppConfig) {
+		config.GetUserConfig().OS.CopyToClipboardCmd = "printf '%s' {{text}} > clipboard"
+	},
+
+	SetupRepo: func(shell *Shell) {
+		shell.CreateDir("dir")
+		shell.CreateFileAndAdd("dir/file1", "1st line\n")
+		shell.Commit("one")
+		shell.CreateFileAndAdd("dir/file2", "2nd line\n")
+		shell.Commit("two")
+	},
+
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Commits().
+			Focus().
+			Lines(
+				Contains("two").IsSelected(),
+				Contains("one"),
+			).
+			Press(keys.Commits.CopyCommitAttributeToClipboard)
+
+		t.ExpectPopup().Menu().
+			Title(Equals("Copy to clipboard")).
+			Select(Contains("Tree file list")).
+			Confirm()
+
+		t.ExpectToast(Equals("Tree file list copied to clipboard"))
+
+		t.FileSystem().FileContent("clipboard",
+			Contains("dir/file1").
+				Contains("dir/file2"))
+	},
+})
@@ -0,0 +1,39 @@
+package commit
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+// We're emulating the clipboard by writing to a file called clipboard
+
+var CopyViewContentsToClipboard = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Copy all lines of the commits view to the clipboard",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(config *config.AppConfig) {
+		config.GetUserConfig().OS.CopyToClipboardCmd = "printf '%s' {{text}} > clipboard"
+	},
+
+	SetupRepo: func(shell *Shell) {
+		shell.EmptyCommit("first subject")
+		shell.EmptyCommit("second subject")
+	},
+
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Commits().
+			Focus().
+			Lines(
+				Contains("second subject").IsSelected(),
+				Contains("first subject"),
+			).
+			Press(keys.Universal.CopyViewContents)
+
+		t.ExpectToast(Equals("View contents copied to clipboard"))
+
+		t.FileSystem().FileContent("clipboard",
+			Contains("second subject").
+				Contains("first subject").
+				DoesNotContain("\x1b"))
+	},
+})
@@ -0,0 +1,39 @@
+package conflicts
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+	"github.com/jesseduffield/lazygit/pkg/integration/tests/shared"
+)
+
+var CopyConflictedContentToClipboard = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Copy the content of a conflicted file, including its conflict markers, to the clipboard",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(config *config.AppConfig) {
+		// note: this is required to simulate the clipboard during CI
+		config.GetUserConfig().OS.CopyToClipboardCmd = "printf '%s' {{text}} > clipboard"
+	},
+	SetupRepo: func(shell *Shell) {
+		shared.CreateMergeConflictFile(shell)
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Files().
+			IsFocused().
+			Lines(
+				Contains("UU file").IsSelected(),
+			).
+			Press(keys.Files.CopyFileInfoToClipboard).
+			Tap(func() {
+				t.ExpectPopup().Menu().
+					Title(Equals("Copy to clipboard")).
+					Select(Contains("Conflicted content")).
+					Confirm()
+
+				t.ExpectToast(Equals("Conflicted content copied to clipboard"))
+			})
+
+		t.FileSystem().FileContent("clipboard",
+			Equals("\nThis\nIs\nThe\n<<<<<<< HEAD\nFirst Change\n=======\nSecond Change\n>>>>>>> second-change-branch\nFile\n"))
+	},
+})
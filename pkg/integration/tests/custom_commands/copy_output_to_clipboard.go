@@ -0,0 +1,44 @@
+package custom_commands
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var CopyOutputToClipboard = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Run a command and copy its output to the clipboard",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupRepo: func(shell *Shell) {
+		shell.EmptyCommit("one")
+		shell.EmptyCommit("two")
+	},
+	SetupConfig: func(cfg *config.AppConfig) {
+		// We're emulating the clipboard by writing to a file called clipboard
+		cfg.GetUserConfig().OS.CopyToClipboardCmd = "printf '%s' {{text}} > clipboard"
+
+		trueVal := true
+		cfg.GetUserConfig().CustomCommands = []config.CustomCommand{
+			{
+				Key:                   "X",
+				Context:               "commits",
+				Command:               "git rev-parse {{ .SelectedCommit.Hash }}",
+				CopyOutputToClipboard: &trueVal,
+			},
+		}
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Commits().
+			Focus().
+			Lines(
+				Contains("two").IsSelected(),
+				Contains("one"),
+			).
+			NavigateToLine(Contains("one")).
+			Press("X")
+
+		t.ExpectToast(Equals("Command output copied to clipboard"))
+
+		t.FileSystem().FileContent("clipboard", Equals(t.Git().GetCommitHash("HEAD^")))
+	},
+})
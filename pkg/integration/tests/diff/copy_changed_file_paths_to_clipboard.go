@@ -0,0 +1,48 @@
+package diff
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var CopyChangedFilePathsToClipboard = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Copy the paths of all files in the selection of the commit files view, one per line",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(config *config.AppConfig) {
+		config.GetUserConfig().OS.CopyToClipboardCmd = "printf '%s' {{text}} > clipboard"
+	},
+	SetupRepo: func(shell *Shell) {
+		shell.CreateDir("dir")
+		shell.CreateFileAndAdd("dir/file1", "1st line\n")
+		shell.CreateFileAndAdd("dir/file2", "2nd line\n")
+		shell.Commit("1")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Commits().
+			Focus().
+			Lines(
+				Contains("1").IsSelected(),
+			).
+			PressEnter()
+
+		t.Views().CommitFiles().
+			IsFocused().
+			Lines(
+				Contains("dir").IsSelected(),
+				Contains("file1"),
+				Contains("file2"),
+			).
+			Press(keys.Files.CopyFileInfoToClipboard).
+			Tap(func() {
+				t.ExpectPopup().Menu().
+					Title(Equals("Copy to clipboard")).
+					Select(Contains("Changed file paths")).
+					Confirm().
+					Tap(func() {
+						t.ExpectToast(Equals("Changed file paths copied to clipboard"))
+						expectClipboard(t, Equals("dir/file1\ndir/file2"))
+					})
+			})
+	},
+})
@@ -0,0 +1,49 @@
+package diff
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var CopyDiffIgnoringWhitespaceToClipboard = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Copy the diff of a commit file ignoring whitespace, so that a change of indentation shows no content changes",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(config *config.AppConfig) {
+		config.GetUserConfig().OS.CopyToClipboardCmd = "printf '%s' {{text}} > clipboard"
+	},
+	SetupRepo: func(shell *Shell) {
+		shell.CreateFileAndAdd("file1", "if true {\nreturn\n}\n")
+		shell.Commit("1")
+		shell.UpdateFileAndAdd("file1", "if true {\n\treturn\n}\n")
+		shell.Commit("2")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Commits().
+			Focus().
+			Lines(
+				Contains("2").IsSelected(),
+				Contains("1"),
+			).
+			PressEnter()
+
+		t.Views().CommitFiles().
+			IsFocused().
+			Lines(
+				Contains("file1").IsSelected(),
+			).
+			Press(keys.Files.CopyFileInfoToClipboard).
+			Tap(func() {
+				t.ExpectPopup().Menu().
+					Title(Equals("Copy to clipboard")).
+					Select(Contains("Diff (ignore whitespace)")).
+					Confirm().
+					Tap(func() {
+						t.ExpectToast(Equals("File diff copied to clipboard"))
+						// depending on the git version, there's either just
+						// the header or nothing at all
+						expectClipboard(t, DoesNotContainAnyOf("@@", "return"))
+					})
+			})
+	},
+})
@@ -0,0 +1,103 @@
+package diff
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var CopyDiffWithContextLinesToClipboard = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Copy diffs from the files, commits and commit files views with the number of context lines configured for copying",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(config *config.AppConfig) {
+		config.GetUserConfig().OS.CopyToClipboardCmd = "printf '%s' {{text}} > clipboard"
+		config.GetUserConfig().Gui.CopyDiffContextLines = 0
+	},
+	SetupRepo: func(shell *Shell) {
+		shell.CreateFileAndAdd("file1", "line1\nline2\nline3\nline4\nline5\n")
+		shell.Commit("1")
+		shell.UpdateFileAndAdd("file1", "line1\nline2\nchanged\nline4\nline5\n")
+		shell.Commit("2")
+		shell.UpdateFile("file1", "line1\nline2\nchanged\nline4\nchanged again\n")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Commits().
+			Focus().
+			Lines(
+				Contains("2").IsSelected(),
+				Contains("1"),
+			).
+			PressEnter()
+
+		t.Views().CommitFiles().
+			IsFocused().
+			Lines(
+				Contains("file1").IsSelected(),
+			).
+			Press(keys.Files.CopyFileInfoToClipboard).
+			Tap(func() {
+				t.ExpectPopup().Menu().
+					Title(Equals("Copy to clipboard")).
+					Select(Contains("Diff of selected file")).
+					Confirm().
+					Tap(func() {
+						t.ExpectToast(Equals("File diff copied to clipboard"))
+						// git puts the preceding line into the hunk header as
+						// its function context, so only check the hunk's body
+						expectClipboard(t,
+							Contains("@@ -3 +3 @@").
+								Contains("-line3\n+changed").
+								DoesNotContainAnyOf([]string{"\n line2", "line4"}))
+					})
+			}).
+			PressEscape()
+
+		t.Views().Commits().
+			IsFocused().
+			Press(keys.Commits.CopyCommitAttributeToClipboard).
+			Tap(func() {
+				t.ExpectPopup().Menu().
+					Title(Equals("Copy to clipboard")).
+					Select(Contains("Commit diff")).
+					Confirm()
+
+				t.ExpectToast(Equals("Commit diff copied to clipboard"))
+				expectClipboard(t,
+					Contains("@@ -3 +3 @@").
+						Contains("-line3\n+changed").
+						DoesNotContainAnyOf([]string{"\n line2", "line4"}))
+			}).
+			Press(keys.Commits.CopyCommitAttributeToClipboard).
+			Tap(func() {
+				t.ExpectPopup().Menu().
+					Title(Equals("Copy to clipboard")).
+					Select(Contains("Diff vs working tree")).
+					Confirm()
+
+				t.ExpectToast(Equals("Diff vs working tree copied to clipboard"))
+				expectClipboard(t,
+					Contains("@@ -5 +5 @@").
+						Contains("-line5\n+changed again").
+						DoesNotContainAnyOf([]string{"\n line4", "line3"}))
+			})
+
+		t.Views().Files().
+			Focus().
+			Lines(
+				Contains("file1").IsSelected(),
+			).
+			Press(keys.Files.CopyFileInfoToClipboard).
+			Tap(func() {
+				t.ExpectPopup().Menu().
+					Title(Equals("Copy to clipboard")).
+					Select(Contains("Diff of selected file")).
+					Confirm()
+
+				t.ExpectToast(Equals("File diff copied to clipboard"))
+				expectClipboard(t,
+					Contains("@@ -5 +5 @@").
+						Contains("-line5\n+changed again").
+						DoesNotContainAnyOf([]string{"\n line4", "changed\n"}))
+			})
+	},
+})
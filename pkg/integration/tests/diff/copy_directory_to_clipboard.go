@@ -0,0 +1,68 @@
+package diff
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var CopyDirectoryToClipboard = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "The copy menu allows to copy the path and diff of a selected directory in the commit files",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(config *config.AppConfig) {
+		config.GetUserConfig().OS.CopyToClipboardCmd = "printf '%s' {{text}} > clipboard"
+	},
+	SetupRepo: func(shell *Shell) {
+		shell.CreateDir("dir")
+		shell.CreateFileAndAdd("dir/file1", "1st line\n")
+		shell.CreateFileAndAdd("other", "other\n")
+		shell.Commit("1")
+		shell.CreateFileAndAdd("dir/file1", "1st line\n2nd line\n")
+		shell.CreateFileAndAdd("dir/file2", "file2\n")
+		shell.CreateFileAndAdd("other", "other\nchanged\n")
+		shell.Commit("2")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Commits().
+			Focus().
+			Lines(
+				Contains("2").IsSelected(),
+				Contains("1"),
+			).
+			PressEnter()
+
+		t.Views().CommitFiles().
+			IsFocused().
+			Lines(
+				Contains("dir").IsSelected(),
+				Contains("file1"),
+				Contains("file2"),
+				Contains("other"),
+			).
+			Press(keys.Files.CopyFileInfoToClipboard).
+			Tap(func() {
+				t.ExpectPopup().Menu().
+					Title(Equals("Copy to clipboard")).
+					Select(Contains("Path")).
+					Confirm().
+					Tap(func() {
+						t.ExpectToast(Equals("Directory path copied to clipboard"))
+						expectClipboard(t, Equals("dir"))
+					})
+			}).
+			Press(keys.Files.CopyFileInfoToClipboard).
+			Tap(func() {
+				t.ExpectPopup().Menu().
+					Title(Equals("Copy to clipboard")).
+					Select(Contains("Diff of selected directory")).
+					Confirm().
+					Tap(func() {
+						t.ExpectToast(Equals("Directory diff copied to clipboard"))
+						expectClipboard(t,
+							Contains("diff --git a/dir/file1 b/dir/file1").Contains("+2nd line").
+								Contains("diff --git a/dir/file2 b/dir/file2").Contains("+file2").
+								DoesNotContain("diff --git a/other b/other"))
+					})
+			})
+	},
+})
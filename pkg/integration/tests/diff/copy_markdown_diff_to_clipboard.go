@@ -0,0 +1,49 @@
+package diff
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var CopyMarkdownDiffToClipboard = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Copy the diff of a commit file wrapped in a markdown code block",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(config *config.AppConfig) {
+		config.GetUserConfig().OS.CopyToClipboardCmd = "printf '%s' {{text}} > clipboard"
+	},
+	SetupRepo: func(shell *Shell) {
+		shell.CreateFileAndAdd("file1", "1st line\n")
+		shell.Commit("1")
+		shell.CreateFileAndAdd("file1", "1st line\n2nd line\n")
+		shell.Commit("2")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Commits().
+			Focus().
+			Lines(
+				Contains("2").IsSelected(),
+				Contains("1"),
+			).
+			PressEnter()
+
+		t.Views().CommitFiles().
+			IsFocused().
+			Lines(
+				Contains("file1").IsSelected(),
+			).
+			Press(keys.Files.CopyFileInfoToClipboard).
+			Tap(func() {
+				t.ExpectPopup().Menu().
+					Title(Equals("Copy to clipboard")).
+					Select(Contains("Markdown diff of selected file")).
+					Confirm().
+					Tap(func() {
+						t.ExpectToast(Equals("Diff copied to clipboard as markdown"))
+						expectClipboard(t,
+							MatchesRegexp("(?s)^```diff\n.*```$").
+								Contains("diff --git a/file1 b/file1").Contains("+2nd line"))
+					})
+			})
+	},
+})
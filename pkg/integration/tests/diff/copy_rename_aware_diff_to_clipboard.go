@@ -0,0 +1,51 @@
+package diff
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var CopyRenameAwareDiffToClipboard = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Copy the diff of a commit that renames a file, with rename detection",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(config *config.AppConfig) {
+		config.GetUserConfig().OS.CopyToClipboardCmd = "printf '%s' {{text}} > clipboard"
+	},
+	SetupRepo: func(shell *Shell) {
+		shell.CreateFileAndAdd("old-name", "1st line\n2nd line\n3rd line\n")
+		shell.Commit("1")
+		shell.RunCommand([]string{"git", "mv", "old-name", "new-name"})
+		shell.Commit("2")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Commits().
+			Focus().
+			Lines(
+				Contains("2").IsSelected(),
+				Contains("1"),
+			).
+			PressEnter()
+
+		t.Views().CommitFiles().
+			IsFocused().
+			Lines(
+				Contains("new-name").IsSelected(),
+				Contains("old-name"),
+			).
+			Press(keys.Files.CopyFileInfoToClipboard).
+			Tap(func() {
+				t.ExpectPopup().Menu().
+					Title(Equals("Copy to clipboard")).
+					Select(Contains("Diff (detect renames)")).
+					Confirm().
+					Tap(func() {
+						t.ExpectToast(Equals("All files diff copied to clipboard"))
+						expectClipboard(t,
+							Contains("rename from old-name").
+								Contains("rename to new-name").
+								DoesNotContain("deleted file"))
+					})
+			})
+	},
+})
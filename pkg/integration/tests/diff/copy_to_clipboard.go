@@ -12,6 +12,11 @@ func expectClipboard(t *TestDriver, matcher *TextMatcher) {
 	t.FileSystem().FileContent("clipboard", matcher)
 }
 
+// asserts that nothing has been copied to the simulated clipboard
+func expectNoClipboard(t *TestDriver) {
+	t.FileSystem().PathNotPresent("clipboard")
+}
+
 var CopyToClipboard = NewIntegrationTest(NewIntegrationTestArgs{
 	Description:  "The copy menu allows to copy name and diff of selected/all files",
 	ExtraCmdArgs: []string{},
@@ -0,0 +1,49 @@
+package diff
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var CopyWordDiffToClipboard = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Copy the word diff of a commit file, marking the changed words within a line",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(config *config.AppConfig) {
+		config.GetUserConfig().OS.CopyToClipboardCmd = "printf '%s' {{text}} > clipboard"
+	},
+	SetupRepo: func(shell *Shell) {
+		shell.CreateFileAndAdd("file1", "the quick brown fox\n")
+		shell.Commit("1")
+		shell.UpdateFileAndAdd("file1", "the slow brown fox\n")
+		shell.Commit("2")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Commits().
+			Focus().
+			Lines(
+				Contains("2").IsSelected(),
+				Contains("1"),
+			).
+			PressEnter()
+
+		t.Views().CommitFiles().
+			IsFocused().
+			Lines(
+				Contains("file1").IsSelected(),
+			).
+			Press(keys.Files.CopyFileInfoToClipboard).
+			Tap(func() {
+				t.ExpectPopup().Menu().
+					Title(Equals("Copy to clipboard")).
+					Select(Contains("Word diff")).
+					Confirm().
+					Tap(func() {
+						t.ExpectToast(Equals("File diff copied to clipboard"))
+						expectClipboard(t,
+							Contains("diff --git a/file1 b/file1").
+								Contains("the [-quick-]{+slow+} brown fox"))
+					})
+			})
+	},
+})
@@ -0,0 +1,60 @@
+package staging
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var CopyBlameInfo = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Copy the hash, author and date of the commit that last changed the selected line",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(config *config.AppConfig) {
+		config.GetUserConfig().OS.CopyToClipboardCmd = "printf '%s' {{text}} > clipboard"
+	},
+	SetupRepo: func(shell *Shell) {
+		shell.SetAuthor("Jane Doe", "jane@example.com")
+		shell.CreateFileAndAdd("file1", "1a\n2a\n3a\n")
+		shell.Commit("one")
+
+		shell.UpdateFile("file1", "1a\n3a\n4a\n")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		hash := t.Git().GetCommitHash("HEAD")
+
+		t.Views().Files().
+			IsFocused().
+			Lines(
+				Contains("file1").IsSelected(),
+			).
+			PressEnter()
+
+		t.Views().Staging().
+			IsFocused().
+			SelectedLines(
+				Contains("-2a"),
+			).
+			SelectNextItem().
+			SelectedLines(
+				Contains(" 3a"),
+			).
+			Press(keys.Main.CopyBlameInfo)
+
+		t.ExpectToast(Equals("Blame info copied to clipboard"))
+
+		t.FileSystem().FileContent("clipboard",
+			Contains(hash[:8]+" (Jane Doe, ").Contains(") 3a"))
+
+		t.Views().Staging().
+			SelectNextItem().
+			SelectedLines(
+				Contains("+4a"),
+			).
+			Press(keys.Main.CopyBlameInfo)
+
+		t.ExpectToast(Equals("Blame info copied to clipboard"))
+
+		t.FileSystem().FileContent("clipboard",
+			Contains("00000000 (Not Committed Yet, ").Contains(") 4a"))
+	},
+})
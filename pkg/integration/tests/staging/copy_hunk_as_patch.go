@@ -0,0 +1,50 @@
+package staging
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var CopyHunkAsPatch = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Copy the selected hunk, with the file header, as a patch to the clipboard",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(config *config.AppConfig) {
+		config.GetUserConfig().OS.CopyToClipboardCmd = "printf '%s' {{text}} > clipboard"
+	},
+	SetupRepo: func(shell *Shell) {
+		// need to be working with a few lines so that git perceives it as two separate hunks
+		shell.CreateFileAndAdd("file1", "1a\n2a\n3a\n4a\n5a\n6a\n7a\n8a\n9a\n10a\n11a\n12a\n13a\n14a\n15a\n")
+		shell.Commit("one")
+
+		shell.UpdateFile("file1", "1a\n2a\n3b\n4a\n5a\n6a\n7a\n8a\n9a\n10a\n11a\n12a\n13b\n14a\n15a\n")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Files().
+			IsFocused().
+			Lines(
+				Contains("file1").IsSelected(),
+			).
+			PressEnter()
+
+		t.Views().Staging().
+			IsFocused().
+			SelectedLines(
+				Contains("-3a"),
+			).
+			Press(keys.Universal.NextBlock).
+			SelectedLines(
+				Contains("-13a"),
+			).
+			Press(keys.Main.ToggleSelectHunk).
+			Press(keys.Main.CopySelectedHunkAsPatch)
+
+		t.ExpectToast(Equals("Hunk patch copied to clipboard"))
+
+		t.FileSystem().FileContent("clipboard",
+			Contains("diff --git a/file1 b/file1").
+				Contains("@@ -10,6 +10,6 @@").
+				Contains("-13a\n+13b").
+				DoesNotContain("-3a"))
+	},
+})
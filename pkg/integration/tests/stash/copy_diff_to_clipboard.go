@@ -0,0 +1,42 @@
+package stash
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+// We're emulating the clipboard by writing to a file called clipboard
+
+var CopyDiffToClipboard = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Copy the diff of a stash entry to the clipboard",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(config *config.AppConfig) {
+		config.GetUserConfig().OS.CopyToClipboardCmd = "printf '%s' {{text}} > clipboard"
+	},
+	SetupRepo: func(shell *Shell) {
+		shell.
+			EmptyCommit("blah").
+			CreateFileAndAdd("file-1", "change to stash1").
+			Stash("foo").
+			CreateFileAndAdd("file-2", "change to stash2").
+			Stash("bar")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Stash().
+			Focus().
+			Lines(
+				Contains("On master: bar").IsSelected(),
+				Contains("On master: foo"),
+			).
+			SelectNextItem().
+			Press(keys.Universal.CopyToClipboard)
+
+		t.ExpectToast(Equals("Stash diff copied to clipboard"))
+
+		t.FileSystem().FileContent("clipboard",
+			Contains("diff --git a/file-1 b/file-1").
+				Contains("+change to stash1").
+				DoesNotContain("file-2"))
+	},
+})
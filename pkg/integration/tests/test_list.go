@@ -40,6 +40,7 @@ var tests = []*components.IntegrationTest{
 	bisect.Skip,
 	branch.CheckoutAutostash,
 	branch.CheckoutByName,
+	branch.CopySelectedLineToClipboard,
 	branch.CreateTag,
 	branch.Delete,
 	branch.DeleteMultiple,
@@ -98,8 +99,17 @@ var tests = []*components.IntegrationTest{
 	commit.CommitWithNonMatchingBranchName,
 	commit.CommitWithPrefix,
 	commit.CopyAuthorToClipboard,
+	commit.CopyCombinedRangeDiffToClipboard,
+	commit.CopyCommitHashesToClipboard,
+	commit.CopyDiffForPathToClipboard,
+	commit.CopyDiffStatToClipboard,
+	commit.CopyDiffVsWorkingTreeToClipboard,
+	commit.CopyFormatPatchToClipboard,
 	commit.CopyMessageBodyToClipboard,
+	commit.CopyRangeDiffToClipboard,
 	commit.CopyTagToClipboard,
+	commit.CopyTreeFileListToClipboard,
+	commit.CopyViewContentsToClipboard,
 	commit.CreateAmendCommit,
 	commit.CreateFixupCommitInBranchStack,
 	commit.CreateTag,
@@ -132,6 +142,7 @@ var tests = []*components.IntegrationTest{
 	config.CustomCommandsInPerRepoConfig,
 	config.NegativeRefspec,
 	config.RemoteNamedStar,
+	conflicts.CopyConflictedContentToClipboard,
 	conflicts.Filter,
 	conflicts.ResolveExternally,
 	conflicts.ResolveMultipleFiles,
@@ -141,6 +152,7 @@ var tests = []*components.IntegrationTest{
 	custom_commands.AccessCommitProperties,
 	custom_commands.BasicCommand,
 	custom_commands.CheckForConflicts,
+	custom_commands.CopyOutputToClipboard,
 	custom_commands.CustomCommandsSubmenu,
 	custom_commands.FormPrompts,
 	custom_commands.GlobalContext,
@@ -169,7 +181,15 @@ var tests = []*components.IntegrationTest{
 	demo.StageLines,
 	demo.Undo,
 	demo.WorktreeCreateFromBranches,
+	diff.CancelCopyToClipboard,
+	diff.CopyChangedFilePathsToClipboard,
+	diff.CopyDiffIgnoringWhitespaceToClipboard,
+	diff.CopyDiffWithContextLinesToClipboard,
+	diff.CopyDirectoryToClipboard,
+	diff.CopyMarkdownDiffToClipboard,
+	diff.CopyRenameAwareDiffToClipboard,
 	diff.CopyToClipboard,
+	diff.CopyWordDiffToClipboard,
 	diff.Diff,
 	diff.DiffAndApplyPatch,
 	diff.DiffCommits,
@@ -297,6 +317,7 @@ var tests = []*components.IntegrationTest{
 	patch_building.ToggleRange,
 	reflog.Checkout,
 	reflog.CherryPick,
+	reflog.CopyReflogEntryToClipboard,
 	reflog.DoNotShowBranchMarkersInReflogSubcommits,
 	reflog.Patch,
 	reflog.Reset,
@@ -306,6 +327,8 @@ var tests = []*components.IntegrationTest{
 	shell_commands.EditHistory,
 	shell_commands.History,
 	shell_commands.OmitFromHistory,
+	staging.CopyBlameInfo,
+	staging.CopyHunkAsPatch,
 	staging.DiffChangeScreenMode,
 	staging.DiffContextChange,
 	staging.DiscardAllChanges,
@@ -315,6 +338,7 @@ var tests = []*components.IntegrationTest{
 	staging.StageRanges,
 	stash.Apply,
 	stash.ApplyPatch,
+	stash.CopyDiffToClipboard,
 	stash.CreateBranch,
 	stash.Drop,
 	stash.Pop,
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -10,6 +11,26 @@ func UnixToTimeAgo(timestamp int64) string {
 	return formatSecondsAgo(now - timestamp)
 }
 
+// TIME_AGO_CELL_WIDTH is the width of the strings returned by UnixToTimeAgoCell
+const TIME_AGO_CELL_WIDTH = 4
+
+// UnixToTimeAgoCell is like UnixToTimeAgo, but relative to the given time and
+// right-aligned in a cell of fixed width, followed by a space, so that a column
+// of them stays aligned: e.g. " 2h ", "11M ". A zero timestamp (i.e. a missing
+// date) gives a blank cell, a date in the future counts as "0s", and anything
+// from a century ago or more is capped to "99y".
+func UnixToTimeAgoCell(now time.Time, timestamp int64) string {
+	if timestamp == 0 {
+		return strings.Repeat(" ", TIME_AGO_CELL_WIDTH)
+	}
+
+	timeAgo := formatSecondsAgo(max(now.Unix()-timestamp, 0))
+	if len(timeAgo) > TIME_AGO_CELL_WIDTH-1 {
+		timeAgo = "99y"
+	}
+	return fmt.Sprintf("%*s ", TIME_AGO_CELL_WIDTH-1, timeAgo)
+}
+
 const (
 	SECONDS_IN_SECOND = 1
 	SECONDS_IN_MINUTE = 60
@@ -2,6 +2,9 @@ package utils
 
 import (
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestFormatSecondsAgo(t *testing.T) {
@@ -95,3 +98,50 @@ func TestFormatSecondsAgo(t *testing.T) {
 		})
 	}
 }
+
+func TestUnixToTimeAgoCell(t *testing.T) {
+	now := time.Unix(SECONDS_IN_YEAR*200, 0)
+
+	tests := []struct {
+		name      string
+		timestamp int64
+		want      string
+	}{
+		{
+			name:      "seconds",
+			timestamp: now.Unix() - 5,
+			want:      " 5s ",
+		},
+		{
+			name:      "hours",
+			timestamp: now.Unix() - 2*SECONDS_IN_HOUR,
+			want:      " 2h ",
+		},
+		{
+			name:      "months",
+			timestamp: now.Unix() - 11*SECONDS_IN_MONTH,
+			want:      "11M ",
+		},
+		{
+			name:      "missing date",
+			timestamp: 0,
+			want:      "    ",
+		},
+		{
+			name:      "in the future",
+			timestamp: now.Unix() + SECONDS_IN_DAY,
+			want:      " 0s ",
+		},
+		{
+			name:      "more than a century ago",
+			timestamp: now.Unix() - 150*SECONDS_IN_YEAR,
+			want:      "99y ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, UnixToTimeAgoCell(now, tt.timestamp))
+		})
+	}
+}
@@ -201,6 +201,19 @@ func ShortHash(hash string) string {
 	return hash[:COMMIT_HASH_SHORT_SIZE]
 }
 
+// EqualHashes tells whether two commit hashes refer to the same commit, where
+// either of them may be abbreviated: they're compared up to the length of the
+// shorter one. An empty hash never matches anything (we use it to mean that
+// there's no commit, e.g. no selected commit).
+func EqualHashes(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+
+	length := min(len(a), len(b))
+	return a[:length] == b[:length]
+}
+
 // Returns comma-separated list of paths, with ellipsis if there are more than 3
 // e.g. "foo, bar, baz, [...3 more]"
 func FormatPaths(paths []string) string {
@@ -275,3 +275,28 @@ func BenchmarkStringWidthNonAsciiOptimized(b *testing.B) {
 		StringWidth("some non-ASCII string 🍉")
 	}
 }
+
+func TestEqualHashes(t *testing.T) {
+	fullHash := "3a5f2c9e1b7d4f6a8c0e2b4d6f8a1c3e5b7d9f0a"
+
+	tests := []struct {
+		a, b     string
+		expected bool
+	}{
+		// either side may be abbreviated, to any length
+		{a: fullHash[:8], b: fullHash, expected: true},
+		{a: fullHash[:12], b: fullHash[:20], expected: true},
+		{a: fullHash, b: fullHash[:4], expected: true},
+		{a: fullHash, b: fullHash, expected: true},
+		{a: fullHash[:8], b: "3a5f2c9f", expected: false},
+		{a: fullHash, b: "3a5f2c9e1b7d4f6a8c0e2b4d6f8a1c3e5b7d9f0b", expected: false},
+		// an empty hash means no commit, so it never matches
+		{a: "", b: fullHash, expected: false},
+		{a: fullHash, b: "", expected: false},
+		{a: "", b: "", expected: false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, EqualHashes(test.a, test.b), "%s vs %s", test.a, test.b)
+	}
+}